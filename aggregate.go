@@ -0,0 +1,213 @@
+package fixed
+
+import "slices"
+
+// Aggregator computes aggregates over slices of Fixed. Sums and products are accumulated exactly in 128 bits, so
+// there is no intermediate overflow, and results are rounded once. The zero value propagates NaN and rounds half-up.
+// A result that is out of range is NaNOverflow. The package level functions use the zero value.
+type Aggregator struct {
+	// SkipNaN ignores NaN values rather than returning NaN
+	SkipNaN bool
+	// Mode is the rounding used for results which cannot be represented exactly
+	Mode RoundingMode
+}
+
+// Sum returns the sum of the values, see Aggregator
+func Sum(values []Fixed) Fixed {
+	return Aggregator{}.Sum(values)
+}
+
+// Mean returns the arithmetic mean of the values, see Aggregator
+func Mean(values []Fixed) Fixed {
+	return Aggregator{}.Mean(values)
+}
+
+// Min returns the smallest of the values, see Aggregator
+func Min(values []Fixed) Fixed {
+	return Aggregator{}.Min(values)
+}
+
+// Max returns the largest of the values, see Aggregator
+func Max(values []Fixed) Fixed {
+	return Aggregator{}.Max(values)
+}
+
+// Median returns the median of the values, see Aggregator
+func Median(values []Fixed) Fixed {
+	return Aggregator{}.Median(values)
+}
+
+// WeightedMean returns the mean of the values weighted by weights, see Aggregator
+func WeightedMean(values, weights []Fixed) Fixed {
+	return Aggregator{}.WeightedMean(values, weights)
+}
+
+// VWAP returns the volume weighted average price of trades, see Aggregator
+func VWAP(prices, qtys []Fixed) Fixed {
+	return Aggregator{}.VWAP(prices, qtys)
+}
+
+// sum returns the exact sum of the finite values and the number of values summed. special is the result if it is
+// not finite, i.e. a NaN which must be propagated, the sum of the infinite values, or NaNOverflow
+func (a Aggregator) sum(values []Fixed) (sum int128, n int64, special Fixed) {
+	var ok bool
+	for _, v := range values {
+		if v.IsNaN() {
+			if a.SkipNaN {
+				continue
+			}
+			return sum, n, v
+		}
+		n++
+		if v.IsInf(0) {
+			special = special.Add(v)
+			continue
+		}
+		if sum, ok = sum.add(int128From(v.fp)); !ok {
+			return sum, n, NaNOverflow
+		}
+	}
+	return sum, n, special
+}
+
+// Sum returns the sum of the values, ZERO if there are none. If a value is NaN and SkipNaN is false, that NaN is
+// returned. Infinities are summed as by Add
+func (a Aggregator) Sum(values []Fixed) Fixed {
+	sum, _, special := a.sum(values)
+	if !special.IsFinite() {
+		return special
+	}
+	fp, ok := sum.int64()
+	if !ok || fp > maxFP || fp < -maxFP {
+		return NaNOverflow
+	}
+	return Fixed{fp: fp}
+}
+
+// Mean returns the arithmetic mean of the values, rounded using Mode. NaN is returned if there are no values. If a
+// value is NaN and SkipNaN is false, that NaN is returned. The mean of values including infinities is their sum
+func (a Aggregator) Mean(values []Fixed) Fixed {
+	sum, n, special := a.sum(values)
+	if !special.IsFinite() {
+		return special
+	}
+	if n == 0 {
+		return NaN
+	}
+	fp, _ := sum.divRound(n, a.Mode)
+	return Fixed{fp: fp}
+}
+
+// extreme returns the value for which better(value, current) holds against all others
+func (a Aggregator) extreme(values []Fixed, better func(f, f0 int64) bool) Fixed {
+	result := NaN
+	for _, v := range values {
+		if v.IsNaN() {
+			if a.SkipNaN {
+				continue
+			}
+			return v
+		}
+		if result.IsNaN() || better(v.fp, result.fp) {
+			result = v
+		}
+	}
+	return result
+}
+
+// Min returns the smallest of the values. NaN is returned if there are no values, and if a value is NaN and SkipNaN
+// is false, that NaN
+func (a Aggregator) Min(values []Fixed) Fixed {
+	return a.extreme(values, func(f, f0 int64) bool { return f < f0 })
+}
+
+// Max returns the largest of the values. NaN is returned if there are no values, and if a value is NaN and SkipNaN
+// is false, that NaN
+func (a Aggregator) Max(values []Fixed) Fixed {
+	return a.extreme(values, func(f, f0 int64) bool { return f > f0 })
+}
+
+// Median returns the median of the values. If there is an even number of values, it is the mean of the middle two,
+// rounded using Mode. NaN is returned if there are no values, and if a value is NaN and SkipNaN is false, that NaN.
+// The values are not modified
+func (a Aggregator) Median(values []Fixed) Fixed {
+	sorted := make([]int64, 0, len(values))
+	for _, v := range values {
+		if v.IsNaN() {
+			if a.SkipNaN {
+				continue
+			}
+			return v
+		}
+		sorted = append(sorted, v.fp)
+	}
+	n := len(sorted)
+	if n == 0 {
+		return NaN
+	}
+	slices.Sort(sorted)
+	if n%2 == 1 {
+		return Fixed{fp: sorted[n/2]}
+	}
+	lo, hi := Fixed{fp: sorted[n/2-1]}, Fixed{fp: sorted[n/2]}
+	if !lo.IsFinite() || !hi.IsFinite() {
+		return addSpecial(lo, hi)
+	}
+	sum, _ := int128From(sorted[n/2-1]).add(int128From(sorted[n/2]))
+	fp, _ := sum.divRound(2, a.Mode)
+	return Fixed{fp: fp}
+}
+
+// sumProducts returns the exact sums of values[i]*weights[i] and of the weights. special is NaN if the result is
+// not finite, i.e. a NaN which must be propagated, NaN for mismatched lengths or an infinite value, or NaNOverflow
+func (a Aggregator) sumProducts(values, weights []Fixed) (products, sum int128, special Fixed) {
+	if len(values) != len(weights) {
+		return products, sum, NaN
+	}
+	var ok bool
+	for i, v := range values {
+		w := weights[i]
+		if !v.IsFinite() || !w.IsFinite() {
+			if a.SkipNaN && (v.IsNaN() || w.IsNaN()) {
+				continue
+			}
+			return products, sum, firstNaN(v, w)
+		}
+		if products, ok = products.add(mul128(v.fp, w.fp)); !ok {
+			return products, sum, NaNOverflow
+		}
+		if sum, ok = sum.add(int128From(w.fp)); !ok {
+			return products, sum, NaNOverflow
+		}
+	}
+	return products, sum, special
+}
+
+// WeightedMean returns sum(values[i]*weights[i]) / sum(weights), computed exactly and rounded once using Mode.
+// NaN is returned if the slices differ in length, or a value or weight is infinite. If a value or weight is NaN and
+// SkipNaN is false, that NaN is returned. With SkipNaN, a pair is skipped if either is NaN. If the weights sum to zero
+// NaNDivByZero is returned
+func (a Aggregator) WeightedMean(values, weights []Fixed) Fixed {
+	products, sum, special := a.sumProducts(values, weights)
+	if !special.IsFinite() {
+		return special
+	}
+	w, ok := sum.int64()
+	if !ok {
+		return NaNOverflow
+	}
+	if w == 0 {
+		return NaNDivByZero
+	}
+	fp, ok := products.divRound(w, a.Mode)
+	if !ok {
+		return NaNOverflow
+	}
+	return Fixed{fp: fp}
+}
+
+// VWAP returns the volume weighted average price of trades with the given prices and quantities, i.e. the mean of
+// the prices weighted by the quantities. See WeightedMean
+func (a Aggregator) VWAP(prices, qtys []Fixed) Fixed {
+	return a.WeightedMean(prices, qtys)
+}
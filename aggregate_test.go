@@ -0,0 +1,123 @@
+package fixed_test
+
+import (
+	"testing"
+
+	. "github.com/robaho/fixed"
+)
+
+func fixeds(values ...string) []Fixed {
+	var result []Fixed
+	for _, s := range values {
+		result = append(result, NewS(s))
+	}
+	return result
+}
+
+func TestSum(t *testing.T) {
+	if f := Sum(fixeds("1.1", "2.2", "-0.3")); f.String() != "3" {
+		t.Error("should be equal", f, "3")
+	}
+	if f := Sum(nil); !f.Equal(ZERO) {
+		t.Error("should be zero", f)
+	}
+	if f := Sum(fixeds("1", "NaN")); !f.IsNaN() {
+		t.Error("should be NaN", f)
+	}
+	if f := (Aggregator{SkipNaN: true}).Sum(fixeds("1", "NaN", "2")); f.String() != "3" {
+		t.Error("should be equal", f, "3")
+	}
+	// intermediate overflow does not matter if the result is in range
+	big := fixeds("99999999999", "99999999999", "-99999999999", "-99999999999", "1")
+	if f := Sum(big); f.String() != "1" {
+		t.Error("should be equal", f, "1")
+	}
+	if f := Sum(fixeds("99999999999", "99999999999")); !f.IsNaN() {
+		t.Error("should be NaN", f)
+	}
+}
+
+func TestMean(t *testing.T) {
+	if f := Mean(fixeds("1", "2", "4")); f.String() != "2.3333333" {
+		t.Error("should be equal", f, "2.3333333")
+	}
+	if f := (Aggregator{Mode: RoundUp}).Mean(fixeds("1", "2", "4")); f.String() != "2.3333334" {
+		t.Error("should be equal", f, "2.3333334")
+	}
+	if f := Mean(fixeds("99999999999", "99999999999", "99999999999")); f.String() != "99999999999" {
+		t.Error("should be equal", f, "99999999999")
+	}
+	if f := Mean(nil); !f.IsNaN() {
+		t.Error("should be NaN", f)
+	}
+	if f := (Aggregator{SkipNaN: true}).Mean(fixeds("NaN", "1", "2")); f.String() != "1.5" {
+		t.Error("should be equal", f, "1.5")
+	}
+}
+
+func TestMinMax(t *testing.T) {
+	values := fixeds("3", "-1.5", "7.25", "0")
+	if f := Min(values); f.String() != "-1.5" {
+		t.Error("should be equal", f, "-1.5")
+	}
+	if f := Max(values); f.String() != "7.25" {
+		t.Error("should be equal", f, "7.25")
+	}
+	values = append(values, NaN)
+	if f := Max(values); !f.IsNaN() {
+		t.Error("should be NaN", f)
+	}
+	if f := (Aggregator{SkipNaN: true}).Min(values); f.String() != "-1.5" {
+		t.Error("should be equal", f, "-1.5")
+	}
+	if f := Min(nil); !f.IsNaN() {
+		t.Error("should be NaN", f)
+	}
+}
+
+func TestMedian(t *testing.T) {
+	values := fixeds("5", "1", "3")
+	if f := Median(values); f.String() != "3" {
+		t.Error("should be equal", f, "3")
+	}
+	if values[0].String() != "5" {
+		t.Error("values should not be modified", values)
+	}
+	if f := Median(fixeds("4", "1", "2", "3")); f.String() != "2.5" {
+		t.Error("should be equal", f, "2.5")
+	}
+	if f := Median(fixeds("0.0000001", "0.0000002")); f.String() != "0.0000002" {
+		t.Error("should be equal", f, "0.0000002")
+	}
+	if f := (Aggregator{Mode: RoundHalfEven}).Median(fixeds("0.0000001", "0.0000002")); f.String() != "0.0000002" {
+		t.Error("should be equal", f, "0.0000002")
+	}
+	if f := (Aggregator{Mode: RoundDown}).Median(fixeds("0.0000001", "0.0000002")); f.String() != "0.0000001" {
+		t.Error("should be equal", f, "0.0000001")
+	}
+	if f := Median(fixeds("1", "NaN")); !f.IsNaN() {
+		t.Error("should be NaN", f)
+	}
+}
+
+func TestWeightedMean(t *testing.T) {
+	if f := WeightedMean(fixeds("10", "20"), fixeds("1", "3")); f.String() != "17.5" {
+		t.Error("should be equal", f, "17.5")
+	}
+	if f := VWAP(fixeds("100.01", "100.02", "100.05"), fixeds("300", "200", "200")); f.String() != "100.0242857" {
+		t.Error("should be equal", f, "100.0242857")
+	}
+	// products overflow int64 but not the 128 bit accumulator
+	if f := VWAP(fixeds("50000", "50001"), fixeds("1000000", "1000000")); f.String() != "50000.5" {
+		t.Error("should be equal", f, "50000.5")
+	}
+	if f := WeightedMean(fixeds("1", "2"), fixeds("1")); !f.IsNaN() {
+		t.Error("should be NaN", f)
+	}
+	if f := WeightedMean(fixeds("1", "2"), fixeds("1", "-1")); !f.IsNaN() {
+		t.Error("should be NaN", f)
+	}
+	if f := (Aggregator{SkipNaN: true}).VWAP(fixeds("10", "NaN", "20"), fixeds("1", "5", "NaN")); f.String() != "10" {
+		t.Error("should be equal", f, "10")
+	}
+}
@@ -0,0 +1,37 @@
+package fixed
+
+// Append appends the string form of f, as returned by String, to buf and returns the extended buffer. It does not
+// allocate if buf has sufficient capacity
+func (f Fixed) Append(buf []byte) []byte {
+	if !f.IsFinite() {
+		return append(buf, f.String()...)
+	}
+	var tmp [24]byte
+	b := itoa(tmp[:], f.fp)
+	point := len(b) - nPlaces - 1
+	end := len(b)
+	for end > point+1 && b[end-1] == '0' {
+		end--
+	}
+	if end == point+1 {
+		end = point
+	}
+	return append(buf, b[:end]...)
+}
+
+// AppendN appends the string form of f with the specified number of decimal places, as returned by StringN, to buf
+// and returns the extended buffer. decimals is limited to the range [0,7]. It does not allocate if buf has sufficient
+// capacity
+func (f Fixed) AppendN(buf []byte, decimals int) []byte {
+	if !f.IsFinite() {
+		return append(buf, f.String()...)
+	}
+	var tmp [24]byte
+	b := itoa(tmp[:], f.fp)
+	point := len(b) - nPlaces - 1
+	decimals = clampPlaces(decimals)
+	if decimals == 0 {
+		return append(buf, b[:point]...)
+	}
+	return append(buf, b[:point+decimals+1]...)
+}
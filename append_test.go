@@ -0,0 +1,40 @@
+package fixed_test
+
+import (
+	"testing"
+
+	. "github.com/robaho/fixed"
+)
+
+func TestAppend(t *testing.T) {
+	for _, s := range []string{"0", "1", "-1", "123.456", "-0.0000001", "99999999999.9999999", "10", "NaN", "+Inf", "-Inf"} {
+		f := NewS(s)
+		if b := f.Append([]byte("x=")); string(b) != "x="+f.String() {
+			t.Error("should be equal", string(b), "x="+f.String())
+		}
+		for n := 0; n <= 7; n++ {
+			if b := f.AppendN(nil, n); string(b) != f.StringN(n) {
+				t.Error("should be equal", string(b), f.StringN(n))
+			}
+		}
+	}
+	if b := NewS("1.25").AppendN(nil, 10); string(b) != "1.2500000" {
+		t.Error("should be equal", string(b), "1.2500000")
+	}
+	if b := NaNOverflow.Append(nil); string(b) != "NaN" {
+		t.Error("should be equal", string(b), "NaN")
+	}
+}
+
+func TestAppendAllocs(t *testing.T) {
+	f := NewS("-123456.789")
+	buf := make([]byte, 0, 64)
+	allocs := testing.AllocsPerRun(100, func() {
+		buf = f.Append(buf[:0])
+		buf = f.AppendN(buf, 2)
+		buf = PosInf.Append(buf)
+	})
+	if allocs != 0 {
+		t.Error("should not allocate", allocs)
+	}
+}
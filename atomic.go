@@ -0,0 +1,54 @@
+package fixed
+
+import "sync/atomic"
+
+// AtomicFixed is a Fixed which can be read and updated atomically from multiple goroutines without locking. The
+// zero value is ZERO. An AtomicFixed must not be copied after first use
+type AtomicFixed struct {
+	v atomic.Int64
+}
+
+// Load atomically loads the value
+func (a *AtomicFixed) Load() Fixed {
+	return Fixed{fp: a.v.Load()}
+}
+
+// Store atomically stores f
+func (a *AtomicFixed) Store(f Fixed) {
+	a.v.Store(f.fp)
+}
+
+// Swap atomically stores f and returns the previous value
+func (a *AtomicFixed) Swap(f Fixed) (old Fixed) {
+	return Fixed{fp: a.v.Swap(f.fp)}
+}
+
+// CompareAndSwap atomically stores new if the current value is old, returning true if the swap was made. The values
+// are compared exactly, so unlike Equal, NaN matches NaN
+func (a *AtomicFixed) CompareAndSwap(old, new Fixed) (swapped bool) {
+	return a.v.CompareAndSwap(old.fp, new.fp)
+}
+
+// Add atomically adds delta and returns the new value. NaN and infinities are handled as by Fixed.Add, and if the sum
+// is out of range the value becomes NaNOverflow. A NaN value remains NaN until it is stored
+func (a *AtomicFixed) Add(delta Fixed) (new Fixed) {
+	for {
+		old := a.v.Load()
+		sum := Fixed{fp: old}.addChecked(delta)
+		if a.v.CompareAndSwap(old, sum.fp) {
+			return sum
+		}
+	}
+}
+
+// addChecked is Add, but returns NaNOverflow if the result is out of range
+func (f Fixed) addChecked(f0 Fixed) Fixed {
+	if !f.IsFinite() || !f0.IsFinite() {
+		return addSpecial(f, f0)
+	}
+	fp := f.fp + f0.fp
+	if fp > maxFP || fp < -maxFP {
+		return NaNOverflow
+	}
+	return Fixed{fp: fp}
+}
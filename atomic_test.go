@@ -0,0 +1,60 @@
+package fixed_test
+
+import (
+	"sync"
+	"testing"
+
+	. "github.com/robaho/fixed"
+)
+
+func TestAtomicFixed(t *testing.T) {
+	var a AtomicFixed
+	if !a.Load().Equal(ZERO) {
+		t.Error("zero value should be ZERO", a.Load())
+	}
+	a.Store(NewS("1.5"))
+	if f := a.Add(NewS("2.25")); f.String() != "3.75" {
+		t.Error("should be equal", f, "3.75")
+	}
+	if old := a.Swap(NewS("10")); old.String() != "3.75" {
+		t.Error("should be equal", old, "3.75")
+	}
+	if a.CompareAndSwap(NewS("9"), NewS("11")) {
+		t.Error("should not swap")
+	}
+	if !a.CompareAndSwap(NewS("10"), NewS("11")) || a.Load().String() != "11" {
+		t.Error("should swap", a.Load())
+	}
+	a.Add(NaN)
+	if !a.Load().IsNaN() {
+		t.Error("should be NaN", a.Load())
+	}
+	if !a.CompareAndSwap(NaN, ZERO) {
+		t.Error("NaN should match NaN")
+	}
+	a.Store(NewS("99999999999"))
+	if f := a.Add(NewS("1")); !f.IsNaN() {
+		t.Error("overflow should be NaN", f)
+	}
+}
+
+func TestAtomicFixedConcurrent(t *testing.T) {
+	var a AtomicFixed
+	var wg sync.WaitGroup
+	delta := NewS("0.0000001")
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				a.Add(delta)
+				a.Add(NewS("1"))
+				a.Add(NewS("-1"))
+			}
+		}()
+	}
+	wg.Wait()
+	if f := a.Load(); f.String() != "0.0008" {
+		t.Error("should be equal", f, "0.0008")
+	}
+}
@@ -0,0 +1,93 @@
+package fixed
+
+// The batch functions apply the scalar operations elementwise, with identical results including NaN handling. They
+// are written as simple loops over re-sliced operands so the compiler can eliminate the bounds checks. dst may alias
+// the operands. They panic if an operand is shorter than dst.
+
+// AddSlices sets dst[i] = a[i].Add(b[i])
+func AddSlices(dst, a, b []Fixed) {
+	a = a[:len(dst)]
+	b = b[:len(dst)]
+	for i := range dst {
+		dst[i] = a[i].Add(b[i])
+	}
+}
+
+// SubSlices sets dst[i] = a[i].Sub(b[i])
+func SubSlices(dst, a, b []Fixed) {
+	a = a[:len(dst)]
+	b = b[:len(dst)]
+	for i := range dst {
+		dst[i] = a[i].Sub(b[i])
+	}
+}
+
+// MulSlices sets dst[i] = a[i].Mul(b[i])
+func MulSlices(dst, a, b []Fixed) {
+	a = a[:len(dst)]
+	b = b[:len(dst)]
+	for i := range dst {
+		dst[i] = a[i].Mul(b[i])
+	}
+}
+
+// ScaleSlice sets dst[i] = a[i].Mul(k)
+func ScaleSlice(dst, a []Fixed, k Fixed) {
+	a = a[:len(dst)]
+	for i := range dst {
+		dst[i] = a[i].Mul(k)
+	}
+}
+
+// SumSlice returns the sum of the values, the same as adding them with Add. Unlike Sum, the result is not checked
+// for overflow
+func SumSlice(a []Fixed) Fixed {
+	var sum int64
+	for _, v := range a {
+		if !v.IsFinite() {
+			return sumSpecial(a)
+		}
+		sum += v.fp
+	}
+	return Fixed{fp: sum}
+}
+
+// sumSpecial is SumSlice for values which include NaN or infinities
+func sumSpecial(a []Fixed) Fixed {
+	var sum Fixed
+	for _, v := range a {
+		sum = sum.Add(v)
+	}
+	return sum
+}
+
+// DotProduct returns the sum of a[i]*b[i]. The products are accumulated exactly in 128 bits and the result rounded
+// half-up once, so it may differ in the last place from summing the results of Mul. NaN and infinities are handled as
+// by Mul and Add, and if the result is out of range, NaNOverflow is returned. It panics if b is shorter than a
+func DotProduct(a, b []Fixed) Fixed {
+	b = b[:len(a)]
+	var sum int128
+	var ok bool
+	for i, v := range a {
+		if !v.IsFinite() || !b[i].IsFinite() {
+			return dotSpecial(a, b)
+		}
+		if sum, ok = sum.add(mul128(v.fp, b[i].fp)); !ok {
+			return NaNOverflow
+		}
+	}
+	fp, ok := sum.divRound(scale, RoundHalfUp)
+	if !ok || fp > maxFP || fp < -maxFP {
+		return NaNOverflow
+	}
+	return Fixed{fp: fp}
+}
+
+// dotSpecial is DotProduct for values which include NaN or infinities, where the result is not finite
+func dotSpecial(a, b []Fixed) Fixed {
+	var sum Fixed
+	for i, v := range a {
+		sum = sum.Add(v.Mul(b[i]))
+	}
+	return sum
+}
@@ -0,0 +1,80 @@
+package fixed_test
+
+import (
+	"testing"
+
+	. "github.com/robaho/fixed"
+)
+
+func TestBatchMatchesScalar(t *testing.T) {
+	a := fixeds("1.5", "-2.25", "NaN", "123456.789", "0.0000001", "0")
+	b := fixeds("2", "3.1", "1", "NaN", "0.5", "-7")
+	k := NewS("1.0001")
+	dst := make([]Fixed, len(a))
+
+	check := func(name string, expected func(i int) Fixed) {
+		for i := range dst {
+			e := expected(i)
+			if dst[i].IsNaN() != e.IsNaN() || (!e.IsNaN() && !dst[i].Equal(e)) {
+				t.Error(name, "should be equal", i, dst[i], e)
+			}
+		}
+	}
+
+	AddSlices(dst, a, b)
+	check("AddSlices", func(i int) Fixed { return a[i].Add(b[i]) })
+	SubSlices(dst, a, b)
+	check("SubSlices", func(i int) Fixed { return a[i].Sub(b[i]) })
+	MulSlices(dst, a, b)
+	check("MulSlices", func(i int) Fixed { return a[i].Mul(b[i]) })
+	ScaleSlice(dst, a, k)
+	check("ScaleSlice", func(i int) Fixed { return a[i].Mul(k) })
+	ScaleSlice(dst, a, NaN)
+	check("ScaleSlice", func(i int) Fixed { return NaN })
+
+	// aliasing the destination
+	c := fixeds("1", "2")
+	AddSlices(c, c, c)
+	if c[0].String() != "2" || c[1].String() != "4" {
+		t.Error("wrong result", c)
+	}
+}
+
+func TestBatchPanicsOnShortOperand(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("The code did not panic")
+		}
+	}()
+	AddSlices(make([]Fixed, 3), fixeds("1", "2", "3"), fixeds("1"))
+}
+
+func TestSumSlice(t *testing.T) {
+	if f := SumSlice(fixeds("1.1", "2.2", "3.3")); f.String() != "6.6" {
+		t.Error("should be equal", f, "6.6")
+	}
+	if f := SumSlice(nil); !f.Equal(ZERO) {
+		t.Error("should be zero", f)
+	}
+	if f := SumSlice(fixeds("1", "NaN")); !f.IsNaN() {
+		t.Error("should be NaN", f)
+	}
+}
+
+func TestDotProduct(t *testing.T) {
+	if f := DotProduct(fixeds("1", "2", "3"), fixeds("4", "5", "6")); f.String() != "32" {
+		t.Error("should be equal", f, "32")
+	}
+	// rounded once rather than per product
+	a := fixeds("0.0000001", "0.0000001", "0.0000001")
+	b := fixeds("0.4", "0.4", "0.4")
+	if f := DotProduct(a, b); f.String() != "0.0000001" {
+		t.Error("should be equal", f, "0.0000001")
+	}
+	if f := DotProduct(fixeds("1", "NaN"), fixeds("1", "1")); !f.IsNaN() {
+		t.Error("should be NaN", f)
+	}
+	if f := DotProduct(fixeds("99999999", "1"), fixeds("99999999", "1")); !f.IsNaN() {
+		t.Error("should be NaN", f)
+	}
+}
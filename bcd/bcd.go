@@ -0,0 +1,240 @@
+// Package bcd encodes and decodes fixed.Fixed as the packed decimal (COBOL COMP-3) and zoned decimal fields of fixed
+// width records.
+//
+// A Field describes the picture of a field, the number of digits before and after the implied decimal point, and the
+// sign convention used when encoding, e.g. PIC S9(7)V99 COMP-3 is
+//
+//	bcd.Field{Digits: 7, Places: 2, Sign: bcd.SignCD}
+//
+// which is 5 bytes, with +1234.5 encoded as 00 01 23 45 0C. A packed field holds two digits per byte followed by a
+// sign nibble, and a zoned field one digit per byte, in EBCDIC, with the sign in the zone of the last byte. Decoding
+// accepts any of the standard sign nibbles, A, C, E and F for positive and B and D for negative, whatever the
+// convention of the Field.
+//
+// Conversions are exact. fixed.ErrOverflow is returned if a value does not fit the field, and fixed.ErrPrecision if
+// it has more decimal places than the field, or the field holds non-zero digits beyond the 7 places of a Fixed.
+package bcd
+
+import (
+	"errors"
+
+	"github.com/robaho/fixed"
+)
+
+// SignConvention is the sign nibble written when encoding
+type SignConvention int
+
+const (
+	// SignCD uses C for positive and D for negative, the preferred signs of IBM packed decimal
+	SignCD SignConvention = iota
+	// SignFD uses F for positive and D for negative
+	SignFD
+	// Unsigned uses F, and a negative value is out of range
+	Unsigned
+)
+
+// MaxDigits is the largest number of digits in a Field
+const MaxDigits = 38
+
+const places = 7
+
+const (
+	zone         = 0xf
+	signPlus     = 0xc
+	signMinus    = 0xd
+	signUnsigned = 0xf
+)
+
+var ErrInvalidField = errors.New("bcd: invalid field")
+var ErrLength = errors.New("bcd: wrong field length")
+var ErrFormat = errors.New("bcd: invalid encoding")
+
+// Field is a packed or zoned decimal field with Digits digits before the implied decimal point and Places after
+type Field struct {
+	Digits int
+	Places int
+	Sign   SignConvention
+}
+
+func (f Field) valid() bool {
+	n := f.Digits + f.Places
+	return f.Digits >= 0 && f.Places >= 0 && n > 0 && n <= MaxDigits && f.Sign >= SignCD && f.Sign <= Unsigned
+}
+
+// PackedLen returns the number of bytes of the field when packed
+func (f Field) PackedLen() int {
+	return (f.Digits+f.Places)/2 + 1
+}
+
+// ZonedLen returns the number of bytes of the field when zoned
+func (f Field) ZonedLen() int {
+	return f.Digits + f.Places
+}
+
+// EncodePacked writes v as a packed decimal to dst, which must be PackedLen bytes
+func (f Field) EncodePacked(dst []byte, v fixed.Fixed) error {
+	var digits [MaxDigits]byte
+	sign, err := f.digits(digits[:], v)
+	if err != nil {
+		return err
+	}
+	if len(dst) != f.PackedLen() {
+		return ErrLength
+	}
+	// the digits are right aligned before the sign nibble, with a leading zero nibble if there is an even number
+	n := f.Digits + f.Places
+	dst[len(dst)-1] = digits[n-1]<<4 | sign
+	for i, j := len(dst)-2, n-2; i >= 0; i, j = i-1, j-2 {
+		b := digits[j]
+		if j > 0 {
+			b |= digits[j-1] << 4
+		}
+		dst[i] = b
+	}
+	return nil
+}
+
+// DecodePacked returns the value of the packed decimal src, which must be PackedLen bytes
+func (f Field) DecodePacked(src []byte) (fixed.Fixed, error) {
+	if !f.valid() {
+		return fixed.NaN, ErrInvalidField
+	}
+	if len(src) != f.PackedLen() {
+		return fixed.NaN, ErrLength
+	}
+	var digits [MaxDigits]byte
+	n := f.Digits + f.Places
+	for i, j := len(src)-1, n-1; j >= 0; i, j = i-1, j-2 {
+		digits[j] = src[i] >> 4
+		if j > 0 {
+			digits[j-1] = src[i-1] & 0xf
+		}
+	}
+	if n%2 == 0 && src[0]>>4 != 0 {
+		// the leading pad nibble
+		return fixed.NaN, ErrFormat
+	}
+	return f.value(digits[:n], src[len(src)-1]&0xf)
+}
+
+// EncodeZoned writes v as a zoned decimal to dst, which must be ZonedLen bytes
+func (f Field) EncodeZoned(dst []byte, v fixed.Fixed) error {
+	var digits [MaxDigits]byte
+	sign, err := f.digits(digits[:], v)
+	if err != nil {
+		return err
+	}
+	if len(dst) != f.ZonedLen() {
+		return ErrLength
+	}
+	for i := range dst {
+		dst[i] = zone<<4 | digits[i]
+	}
+	dst[len(dst)-1] = sign<<4 | digits[len(dst)-1]
+	return nil
+}
+
+// DecodeZoned returns the value of the zoned decimal src, which must be ZonedLen bytes
+func (f Field) DecodeZoned(src []byte) (fixed.Fixed, error) {
+	if !f.valid() {
+		return fixed.NaN, ErrInvalidField
+	}
+	if len(src) != f.ZonedLen() {
+		return fixed.NaN, ErrLength
+	}
+	var digits [MaxDigits]byte
+	for i, b := range src {
+		if b>>4 != zone && i < len(src)-1 {
+			return fixed.NaN, ErrFormat
+		}
+		digits[i] = b & 0xf
+	}
+	return f.value(digits[:len(src)], src[len(src)-1]>>4)
+}
+
+// digits sets the first Digits+Places of digits to the digits of v, returning its sign nibble
+func (f Field) digits(digits []byte, v fixed.Fixed) (byte, error) {
+	if !f.valid() {
+		return 0, ErrInvalidField
+	}
+	if v.IsNaN() {
+		return 0, fixed.ErrNaN
+	}
+	if v.IsInf(0) {
+		return 0, fixed.ErrOverflow
+	}
+	raw := v.Raw()
+	neg := raw < 0
+	if neg {
+		raw = -raw
+	}
+	ip, fp := raw/1e7, raw%1e7
+	// the fractional digits, after dropping the trailing places that the field does not hold
+	for i := f.Places; i < places; i++ {
+		if fp%10 != 0 {
+			return 0, fixed.ErrPrecision
+		}
+		fp /= 10
+	}
+	for i := min(f.Places, places) - 1; i >= 0; i-- {
+		digits[f.Digits+i] = byte(fp % 10)
+		fp /= 10
+	}
+	for i := f.Digits - 1; i >= 0; i-- {
+		digits[i] = byte(ip % 10)
+		ip /= 10
+	}
+	if ip != 0 {
+		return 0, fixed.ErrOverflow
+	}
+	switch {
+	case f.Sign == Unsigned && neg:
+		return 0, fixed.ErrOverflow
+	case f.Sign == Unsigned:
+		return signUnsigned, nil
+	case neg:
+		return signMinus, nil
+	case f.Sign == SignFD:
+		return signUnsigned, nil
+	}
+	return signPlus, nil
+}
+
+// value returns the value of the decimal digits, with the implied decimal point Places from the end, and the sign
+// nibble sign
+func (f Field) value(digits []byte, sign byte) (fixed.Fixed, error) {
+	var neg bool
+	switch sign {
+	case 0xa, 0xc, 0xe, 0xf:
+	case 0xb, 0xd:
+		neg = true
+	default:
+		return fixed.NaN, ErrFormat
+	}
+	var v int64
+	overflow := false
+	for i, d := range digits {
+		if d > 9 {
+			return fixed.NaN, ErrFormat
+		}
+		if i-f.Digits >= places {
+			// beyond the places of a Fixed
+			if d != 0 {
+				return fixed.NaN, fixed.ErrPrecision
+			}
+			continue
+		}
+		// an int64 holds 18 digits, and more are out of range
+		if v >= 1e17 {
+			overflow = true
+		}
+		v = v*10 + int64(d)
+	}
+	if overflow {
+		return fixed.NaNOverflow, fixed.ErrOverflow
+	}
+	if neg {
+		v = -v
+	}
+	return fixed.FromScaled(v, -min(f.Places, places))
+}
@@ -0,0 +1,172 @@
+package bcd
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/robaho/fixed"
+)
+
+func TestPacked(t *testing.T) {
+	testCases := []struct {
+		field Field
+		in    string
+		out   string
+	}{
+		{Field{Digits: 5}, "12345", "12345c"},
+		{Field{Digits: 5}, "-12345", "12345d"},
+		{Field{Digits: 3, Places: 2}, "-123.45", "12345d"},
+		{Field{Digits: 4}, "1234", "01234c"},
+		{Field{Digits: 7, Places: 2}, "1234.5", "000123450c"},
+		{Field{Digits: 2, Places: 2, Sign: SignFD}, "1.5", "00150f"},
+		{Field{Digits: 2, Places: 2, Sign: SignFD}, "-1.5", "00150d"},
+		{Field{Digits: 3, Sign: Unsigned}, "7", "007f"},
+		{Field{Digits: 1}, "0", "0c"},
+		{Field{Places: 7}, "-0.0000001", "0000001d"},
+		{Field{Digits: 11, Places: 7}, "99999999999.9999999", "0999999999999999999c"},
+		{Field{Digits: 2, Places: 9}, "12.3456789", "12345678900c"},
+		{Field{Digits: 20, Places: 10}, "-1.25", "0000000000000000000012500000000d"},
+	}
+	for _, tc := range testCases {
+		v := fixed.NewS(tc.in)
+		dst := make([]byte, tc.field.PackedLen())
+		if err := tc.field.EncodePacked(dst, v); err != nil || hex.EncodeToString(dst) != tc.out {
+			t.Error("wrong encoding", tc.in, hex.EncodeToString(dst), tc.out, err)
+		}
+		v0, err := tc.field.DecodePacked(dst)
+		if err != nil || !v0.Equal(v) {
+			t.Error("should be equal", v0, v, err)
+		}
+	}
+}
+
+func TestZoned(t *testing.T) {
+	testCases := []struct {
+		field Field
+		in    string
+		out   string
+	}{
+		{Field{Digits: 3}, "123", "f1f2c3"},
+		{Field{Digits: 3}, "-123", "f1f2d3"},
+		{Field{Digits: 3, Places: 2}, "-1.5", "f0f0f1f5d0"},
+		{Field{Digits: 2, Sign: SignFD}, "42", "f4f2"},
+		{Field{Digits: 4, Sign: Unsigned}, "0", "f0f0f0f0"},
+	}
+	for _, tc := range testCases {
+		v := fixed.NewS(tc.in)
+		dst := make([]byte, tc.field.ZonedLen())
+		if err := tc.field.EncodeZoned(dst, v); err != nil || hex.EncodeToString(dst) != tc.out {
+			t.Error("wrong encoding", tc.in, hex.EncodeToString(dst), tc.out, err)
+		}
+		v0, err := tc.field.DecodeZoned(dst)
+		if err != nil || !v0.Equal(v) {
+			t.Error("should be equal", v0, v, err)
+		}
+	}
+}
+
+func TestEncodeErrors(t *testing.T) {
+	testCases := []struct {
+		field Field
+		in    fixed.Fixed
+		err   error
+	}{
+		{Field{Digits: 3}, fixed.NewS("1000"), fixed.ErrOverflow},
+		{Field{Digits: 3, Places: 2}, fixed.NewS("1.234"), fixed.ErrPrecision},
+		{Field{Digits: 3}, fixed.NewS("0.5"), fixed.ErrPrecision},
+		{Field{Digits: 3, Sign: Unsigned}, fixed.NewS("-1"), fixed.ErrOverflow},
+		{Field{Digits: 3}, fixed.NaN, fixed.ErrNaN},
+		{Field{Digits: 3}, fixed.PosInf, fixed.ErrOverflow},
+		{Field{}, fixed.ZERO, ErrInvalidField},
+		{Field{Digits: 30, Places: 9}, fixed.ZERO, ErrInvalidField},
+		{Field{Digits: -1, Places: 2}, fixed.ZERO, ErrInvalidField},
+		{Field{Digits: 1, Sign: 3}, fixed.ZERO, ErrInvalidField},
+	}
+	for _, tc := range testCases {
+		if err := tc.field.EncodePacked(make([]byte, max(tc.field.PackedLen(), 0)), tc.in); err != tc.err {
+			t.Error("wrong error", tc.field, tc.in, err)
+		}
+		if err := tc.field.EncodeZoned(make([]byte, max(tc.field.ZonedLen(), 0)), tc.in); err != tc.err {
+			t.Error("wrong error", tc.field, tc.in, err)
+		}
+	}
+	f := Field{Digits: 3}
+	if err := f.EncodePacked(make([]byte, 3), fixed.ZERO); err != ErrLength {
+		t.Error("should be a length error", err)
+	}
+	if err := f.EncodeZoned(make([]byte, 2), fixed.ZERO); err != ErrLength {
+		t.Error("should be a length error", err)
+	}
+}
+
+func TestDecode(t *testing.T) {
+	testCases := []struct {
+		field  Field
+		packed string
+		out    string
+		err    error
+	}{
+		// alternate sign nibbles
+		{Field{Digits: 3}, "123a", "123", nil},
+		{Field{Digits: 3}, "123b", "-123", nil},
+		{Field{Digits: 3}, "123e", "123", nil},
+		{Field{Digits: 3}, "123f", "123", nil},
+		{Field{Digits: 3}, "1239", "", ErrFormat},
+		{Field{Digits: 3}, "1a3c", "", ErrFormat},
+		{Field{Digits: 2}, "112c", "", ErrFormat},
+		{Field{Digits: 2}, "012c", "12", nil},
+		{Field{Digits: 2}, "12c", "", ErrLength},
+		{Field{Digits: 19}, "1000000000000000000c", "", fixed.ErrOverflow},
+		{Field{Digits: 19}, "0100000000000000000c", "", fixed.ErrOverflow},
+		{Field{Digits: 19}, "0009999999999999999c", "", fixed.ErrOverflow},
+		{Field{Digits: 19}, "0000099999999999999d", "", fixed.ErrOverflow},
+		{Field{Digits: 19}, "0000000099999999999d", "-99999999999", nil},
+		{Field{Places: 9}, "000000010c", "", fixed.ErrPrecision},
+		{Field{Places: 9}, "000000100c", "0.0000001", nil},
+		{Field{Digits: 0, Places: 0}, "0c", "", ErrInvalidField},
+	}
+	for _, tc := range testCases {
+		src, _ := hex.DecodeString(tc.packed)
+		v, err := tc.field.DecodePacked(src)
+		if err != tc.err {
+			t.Error("wrong error", tc.packed, err, tc.err)
+			continue
+		}
+		if err == nil && v.String() != tc.out {
+			t.Error("should be equal", tc.packed, v, tc.out)
+		}
+	}
+
+	f := Field{Digits: 3, Places: 1}
+	if v, err := f.DecodeZoned([]byte{0xf0, 0xf1, 0xf2, 0xb5}); err != nil || v.String() != "-12.5" {
+		t.Error("should be equal", v, "-12.5", err)
+	}
+	for _, src := range [][]byte{{0xf0, 0xf1, 0xf2, 0x95}, {0xf0, 0xc1, 0xf2, 0xc5}, {0xf0, 0xfa, 0xf2, 0xc5}, {0xf1, 0xf2, 0xc5}} {
+		if _, err := f.DecodeZoned(src); err == nil {
+			t.Error("should be an error", hex.EncodeToString(src))
+		}
+	}
+}
+
+func TestRecord(t *testing.T) {
+	// a fixed width record with an amount PIC S9(9)V99 COMP-3 followed by a rate PIC 9V9(6)
+	amount := Field{Digits: 9, Places: 2}
+	rate := Field{Digits: 1, Places: 6, Sign: Unsigned}
+	record := make([]byte, amount.PackedLen()+rate.ZonedLen())
+	if err := amount.EncodePacked(record[:amount.PackedLen()], fixed.NewS("-1234567.89")); err != nil {
+		t.Fatal(err)
+	}
+	if err := rate.EncodeZoned(record[amount.PackedLen():], fixed.NewS("0.0425")); err != nil {
+		t.Fatal(err)
+	}
+	want, _ := hex.DecodeString("00123456789df0f0f4f2f5f0f0")
+	if !bytes.Equal(record, want) {
+		t.Error("wrong record", hex.EncodeToString(record))
+	}
+	a, err1 := amount.DecodePacked(record[:amount.PackedLen()])
+	r, err2 := rate.DecodeZoned(record[amount.PackedLen():])
+	if err1 != nil || err2 != nil || a.String() != "-1234567.89" || r.String() != "0.0425" {
+		t.Error("wrong values", a, r, err1, err2)
+	}
+}
@@ -0,0 +1,101 @@
+package fixed
+
+import (
+	"math/big"
+)
+
+// conversions to and from the math/big types. A finite Fixed is always exactly representable as a big.Int or big.Rat.
+// ErrNaN is returned when converting NaN, and ErrOverflow for an infinity, except to a big.Float.
+
+var bigScale = big.NewInt(scale)
+var bigMaxFP = big.NewInt(maxFP)
+
+// ToBigInt returns the unscaled value of f, i.e. Raw as a big.Int
+func (f Fixed) ToBigInt() (*big.Int, error) {
+	if err := f.finiteErr(); err != nil {
+		return nil, err
+	}
+	return big.NewInt(f.fp), nil
+}
+
+// FromBigInt creates a Fixed from an unscaled value, as returned by ToBigInt. An error is returned if the value is out
+// of range
+func FromBigInt(i *big.Int) (Fixed, error) {
+	if i.CmpAbs(bigMaxFP) > 0 {
+		return NaNOverflow, ErrOverflow
+	}
+	return Fixed{fp: i.Int64()}, nil
+}
+
+// ToBigRat returns the exact value of f
+func (f Fixed) ToBigRat() (*big.Rat, error) {
+	if err := f.finiteErr(); err != nil {
+		return nil, err
+	}
+	return new(big.Rat).SetFrac(big.NewInt(f.fp), bigScale), nil
+}
+
+// FromBigRat creates a Fixed from r, rounded to 7 decimal places using mode. An error is returned if the value is out
+// of range
+func FromBigRat(r *big.Rat, mode RoundingMode) (Fixed, error) {
+	n := new(big.Int).Mul(r.Num(), bigScale)
+	d := r.Denom()
+	q, rem := n.QuoRem(n, d, new(big.Int))
+	if roundUpBig(q, rem, d, mode) {
+		q.Add(q, big.NewInt(int64(rem.Sign())))
+	}
+	return FromBigInt(q)
+}
+
+// roundUpBig is roundUp for a big.Int quotient and remainder of a division by the positive d. The remainder has the
+// sign of the dividend.
+func roundUpBig(q, r, d *big.Int, mode RoundingMode) bool {
+	if r.Sign() == 0 {
+		return false
+	}
+	neg := r.Sign() < 0
+	switch mode {
+	case RoundDown:
+		return false
+	case RoundUp:
+		return true
+	case RoundFloor:
+		return neg
+	case RoundCeiling:
+		return !neg
+	}
+	c := new(big.Int).Lsh(new(big.Int).Abs(r), 1).Cmp(d)
+	if c != 0 {
+		return c > 0
+	}
+	switch mode {
+	case RoundHalfEven:
+		return q.Bit(0) == 1
+	case RoundHalfDown:
+		return false
+	}
+	return true
+}
+
+// ToBigFloat returns the value of f as a big.Float with 64 bits of precision, rounded to nearest even. Most decimal
+// fractions are not exactly representable in binary. Infinities are preserved
+func (f Fixed) ToBigFloat() (*big.Float, error) {
+	if f.IsInf(0) {
+		return new(big.Float).SetInf(f.fp < 0), nil
+	}
+	r, err := f.ToBigRat()
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Float).SetPrec(64).SetRat(r), nil
+}
+
+// FromBigFloat creates a Fixed from the exact value of bf, rounded to 7 decimal places using mode. Infinities are
+// preserved, and an error is returned if the value is otherwise out of range
+func FromBigFloat(bf *big.Float, mode RoundingMode) (Fixed, error) {
+	if bf.IsInf() {
+		return infOf(int64(bf.Sign())), nil
+	}
+	r, _ := bf.Rat(nil)
+	return FromBigRat(r, mode)
+}
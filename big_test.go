@@ -0,0 +1,83 @@
+package fixed_test
+
+import (
+	"math/big"
+	"testing"
+
+	. "github.com/robaho/fixed"
+)
+
+func TestBigInt(t *testing.T) {
+	i, err := NewS("-123.456").ToBigInt()
+	if err != nil || i.Int64() != -1234560000 {
+		t.Error("should be equal", i, err, -1234560000)
+	}
+	f, err := FromBigInt(i)
+	if err != nil || f.String() != "-123.456" {
+		t.Error("should be equal", f, err, "-123.456")
+	}
+	if _, err := NaN.ToBigInt(); err != ErrNaN {
+		t.Error("should be ErrNaN", err)
+	}
+	if _, err := FromBigInt(new(big.Int).Lsh(big.NewInt(1), 64)); err != ErrOverflow {
+		t.Error("should be ErrOverflow", err)
+	}
+}
+
+func TestBigRat(t *testing.T) {
+	r, err := NewS("0.125").ToBigRat()
+	if err != nil || r.Cmp(big.NewRat(1, 8)) != 0 {
+		t.Error("should be equal", r, err, "1/8")
+	}
+	testCases := []struct {
+		r    *big.Rat
+		mode RoundingMode
+		out  string
+	}{
+		{big.NewRat(1, 3), RoundHalfUp, "0.3333333"},
+		{big.NewRat(2, 3), RoundHalfUp, "0.6666667"},
+		{big.NewRat(-2, 3), RoundDown, "-0.6666666"},
+		{big.NewRat(-1, 3), RoundFloor, "-0.3333334"},
+		{big.NewRat(1, 20000000), RoundHalfEven, "0"},
+		{big.NewRat(3, 20000000), RoundHalfEven, "0.0000002"},
+		{big.NewRat(-1, 20000000), RoundHalfUp, "-0.0000001"},
+		{big.NewRat(1, 1000000000), RoundCeiling, "0.0000001"},
+	}
+	for _, tc := range testCases {
+		f, err := FromBigRat(tc.r, tc.mode)
+		if err != nil || f.String() != tc.out {
+			t.Error("should be equal", tc.r, f, err, tc.out)
+		}
+	}
+	if _, err := FromBigRat(big.NewRat(1e12, 1), RoundHalfUp); err != ErrOverflow {
+		t.Error("should be ErrOverflow", err)
+	}
+	if _, err := NaN.ToBigRat(); err != ErrNaN {
+		t.Error("should be ErrNaN", err)
+	}
+}
+
+func TestBigFloat(t *testing.T) {
+	bf, err := NewS("2.5").ToBigFloat()
+	if err != nil || bf.String() != "2.5" {
+		t.Error("should be equal", bf, err, "2.5")
+	}
+	bf, _ = NewS("0.1").ToBigFloat()
+	f, err := FromBigFloat(bf, RoundHalfUp)
+	if err != nil || f.String() != "0.1" {
+		t.Error("should be equal", f, err, "0.1")
+	}
+	f, err = FromBigFloat(big.NewFloat(1.0/3), RoundDown)
+	if err != nil || f.String() != "0.3333333" {
+		t.Error("should be equal", f, err, "0.3333333")
+	}
+	if f, err := FromBigFloat(new(big.Float).SetInf(true), RoundHalfUp); err != nil || !f.IsInf(-1) {
+		t.Error("should be -Inf", f, err)
+	}
+	if bf, err := PosInf.ToBigFloat(); err != nil || !bf.IsInf() || bf.Sign() != 1 {
+		t.Error("should be +Inf", bf, err)
+	}
+	if _, err := PosInf.ToBigRat(); err != ErrOverflow {
+		t.Error("should be ErrOverflow", err)
+	}
+}
@@ -0,0 +1,70 @@
+package fixed
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// BSON encoding, compatible with the ValueMarshaler and ValueUnmarshaler interfaces of the MongoDB Go driver v2,
+// go.mongodb.org/mongo-driver/v2/bson. A Fixed is stored as a Decimal128, so it can be queried and aggregated
+// numerically by the database.
+
+// BSON element types
+const (
+	bsonString     = 0x02
+	bsonNull       = 0x0a
+	bsonInt32      = 0x10
+	bsonInt64      = 0x12
+	bsonDecimal128 = 0x13
+)
+
+// MarshalBSONValue implements the bson.ValueMarshaler interface, encoding f as a Decimal128 with the trailing zeros of
+// the fraction removed. NaN and infinities are encoded as the Decimal128 NaN and infinities, and the kind of a NaN is preserved
+func (f Fixed) MarshalBSONValue() (byte, []byte, error) {
+	hi, lo := f.toBID128()
+	data := make([]byte, 16)
+	binary.LittleEndian.PutUint64(data, lo)
+	binary.LittleEndian.PutUint64(data[8:], hi)
+	return bsonDecimal128, data, nil
+}
+
+// UnmarshalBSONValue implements the bson.ValueUnmarshaler interface, decoding a Decimal128, a 32 or 64 bit integer,
+// or a string as parsed by NewSErr, so values previously stored as strings can still be read. As with JSON null, null
+// leaves f unchanged. ErrOverflow is returned if the value is out of range, and ErrPrecision if it has more than 7
+// decimal places
+func (f *Fixed) UnmarshalBSONValue(typ byte, data []byte) error {
+	var value Fixed
+	var err error
+	switch typ {
+	case bsonNull:
+		return nil
+	case bsonDecimal128:
+		if len(data) != 16 {
+			return errFormat
+		}
+		value, err = fromBID128(binary.LittleEndian.Uint64(data[8:]), binary.LittleEndian.Uint64(data))
+	case bsonInt32:
+		if len(data) != 4 {
+			return errFormat
+		}
+		value, err = FromScaled(int64(int32(binary.LittleEndian.Uint32(data))), 0)
+	case bsonInt64:
+		if len(data) != 8 {
+			return errFormat
+		}
+		value, err = FromScaled(int64(binary.LittleEndian.Uint64(data)), 0)
+	case bsonString:
+		// the length includes the terminating null
+		if len(data) < 5 || int(binary.LittleEndian.Uint32(data)) != len(data)-4 || data[len(data)-1] != 0 {
+			return errFormat
+		}
+		value, err = NewSErr(string(data[4 : len(data)-1]))
+	default:
+		return fmt.Errorf("cannot decode BSON type 0x%02x: %w", typ, errFormat)
+	}
+	if err != nil {
+		return err
+	}
+	*f = value
+	return nil
+}
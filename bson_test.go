@@ -0,0 +1,108 @@
+package fixed_test
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"testing"
+
+	. "github.com/robaho/fixed"
+)
+
+// decimal128 returns the BSON encoding of the decimal128 with the high and low 64 bits hi and lo
+func decimal128(hi, lo uint64) []byte {
+	data := make([]byte, 16)
+	binary.LittleEndian.PutUint64(data, lo)
+	binary.LittleEndian.PutUint64(data[8:], hi)
+	return data
+}
+
+func TestMarshalBSONValue(t *testing.T) {
+	testCases := []struct {
+		in     Fixed
+		hi, lo uint64
+	}{
+		{ZERO, 0x3040000000000000, 0},
+		{NewS("1"), 0x3040000000000000, 1},
+		{NewS("-1"), 0xb040000000000000, 1},
+		{NewS("0.1"), 0x303e000000000000, 1},
+		{NewS("-1.5"), 0xb03e000000000000, 15},
+		{NewS("1000"), 0x3040000000000000, 1000},
+		{NewS("0.0000001"), 0x3032000000000000, 1},
+		{NewS("99999999999.9999999"), 0x3032000000000000, 999999999999999999},
+		{NaN, 0x7c00000000000000, 0},
+		{PosInf, 0x7800000000000000, 0},
+		{NegInf, 0xf800000000000000, 0},
+	}
+	for _, tc := range testCases {
+		typ, data, err := tc.in.MarshalBSONValue()
+		if err != nil || typ != 0x13 || hex.EncodeToString(data) != hex.EncodeToString(decimal128(tc.hi, tc.lo)) {
+			t.Error("wrong encoding", tc.in, typ, hex.EncodeToString(data), err)
+		}
+		var f Fixed
+		if err := f.UnmarshalBSONValue(typ, data); err != nil || f.Kind() != tc.in.Kind() || f.IsFinite() && !f.Equal(tc.in) {
+			t.Error("should be equal", f, tc.in, err)
+		}
+	}
+	// the kind of a NaN is preserved
+	for _, nan := range []Fixed{NaNDivByZero, NaNOverflow} {
+		typ, data, _ := nan.MarshalBSONValue()
+		var f Fixed
+		if err := f.UnmarshalBSONValue(typ, data); err != nil || f.Kind() != nan.Kind() {
+			t.Error("should be equal", f.Kind(), nan.Kind(), err)
+		}
+	}
+}
+
+func TestUnmarshalBSONValue(t *testing.T) {
+	testCases := []struct {
+		typ  byte
+		data []byte
+		out  string
+		err  error
+	}{
+		// trailing zeros, 1.50000000000000000000000000000000
+		{0x13, decimal128(0x3000000000000000|0x7654423e209, 0x4883674180000000), "1.5", nil},
+		{0x13, decimal128(0x3040000000000000, 0xffffffffffffffff), "", ErrOverflow},
+		{0x13, decimal128(0x3046000000000000, 100000000), "", ErrOverflow},
+		{0x13, decimal128(0x3030000000000000, 1), "", ErrPrecision},
+		// 1E+6144 and 1E-6176
+		{0x13, decimal128(0x5ffe000000000000, 1), "", ErrOverflow},
+		{0x13, decimal128(0x0000000000000000, 1), "", ErrPrecision},
+		// a non-canonical coefficient is zero
+		{0x13, decimal128(0x6c10000000000000, 0), "0", nil},
+		{0x13, decimal128(0x3041ed09bead87c0, 0x378d8e6400000000), "0", nil},
+		{0x13, decimal128(0xfc00000000000000, 0), "NaN", nil},
+		{0x13, decimal128(0x7e00000000000000, 0), "NaN", nil},
+		{0x13, []byte{0}, "", nil},
+		{0x10, []byte{0xfe, 0xff, 0xff, 0xff}, "-2", nil},
+		{0x12, []byte{0x40, 0x42, 0x0f, 0, 0, 0, 0, 0}, "1000000", nil},
+		{0x12, []byte{0, 0, 0, 0, 0, 0, 0, 0x10}, "", ErrOverflow},
+		{0x02, []byte{7, 0, 0, 0, '1', '2', '.', '3', '4', '5', 0}, "12.345", nil},
+		{0x02, []byte{4, 0, 0, 0, 'N', 'a', 'N', 0}, "NaN", nil},
+		{0x02, []byte{3, 0, 0, 0, '1', '2', 0, 0}, "", nil},
+	}
+	for _, tc := range testCases {
+		var f Fixed
+		err := f.UnmarshalBSONValue(tc.typ, tc.data)
+		if tc.out == "" && tc.err == nil {
+			if err == nil {
+				t.Error("should be an error", tc.data, f)
+			}
+			continue
+		}
+		if err != tc.err {
+			t.Error("unexpected error", tc.data, err)
+			continue
+		}
+		if err == nil && f.String() != tc.out {
+			t.Error("should be equal", tc.data, f, tc.out)
+		}
+	}
+	f := NewS("1.5")
+	if err := f.UnmarshalBSONValue(0x0a, nil); err != nil || f.String() != "1.5" {
+		t.Error("null should leave the value unchanged", f, err)
+	}
+	if err := f.UnmarshalBSONValue(0x01, make([]byte, 8)); err == nil {
+		t.Error("should be an error for a double")
+	}
+}
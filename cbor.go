@@ -0,0 +1,197 @@
+package fixed
+
+import (
+	"encoding/binary"
+	"math"
+)
+
+// CBOR encoding, compatible with the Marshaler and Unmarshaler interfaces of github.com/fxamacker/cbor. A finite
+// value is a decimal fraction (RFC 8949 tag 4), the array [exponent, mantissa] with the value mantissa*10^exponent.
+// A decimal fraction cannot be NaN or infinite, so these are encoded as half-precision floats, and the kind of a NaN
+// is lost.
+
+const (
+	cborUint    = 0
+	cborNegInt  = 1
+	cborArray   = 4
+	cborTag     = 6
+	cborSimple  = 7
+	cborDecimal = 4
+)
+
+const (
+	cborNull      = 0xf6
+	cborUndefined = 0xf7
+	cborHalf      = 0xf9
+	cborSingle    = 0xfa
+	cborDouble    = 0xfb
+	// the largest exponent needed, which avoids overflowing an int
+	cborMaxExp = 1 << 20
+)
+
+// MarshalCBOR implements the cbor.Marshaler interface. Trailing zeros of the fraction are removed, so 1.5 is
+// encoded as [-1, 15]
+func (f Fixed) MarshalCBOR() ([]byte, error) {
+	switch {
+	case f.IsNaN():
+		return []byte{cborHalf, 0x7e, 0x00}, nil
+	case f.IsInf(1):
+		return []byte{cborHalf, 0x7c, 0x00}, nil
+	case f.IsInf(-1):
+		return []byte{cborHalf, 0xfc, 0x00}, nil
+	}
+	m, exp := f.trimZeros()
+	data := make([]byte, 0, 16)
+	data = cborAppendHead(data, cborTag, cborDecimal)
+	data = cborAppendHead(data, cborArray, 2)
+	data = cborAppendInt(data, int64(exp))
+	return cborAppendInt(data, m), nil
+}
+
+// UnmarshalCBOR implements the cbor.Unmarshaler interface, decoding a decimal fraction, an integer, or a NaN or
+// infinite float. As with JSON null, null and undefined leave f unchanged. ErrOverflow is returned if the value is out
+// of range, and ErrPrecision if it has more than 7 decimal places
+func (f *Fixed) UnmarshalCBOR(data []byte) error {
+	if len(data) == 0 {
+		return errFormat
+	}
+	if data[0] == cborNull || data[0] == cborUndefined {
+		if len(data) != 1 {
+			return errFormat
+		}
+		return nil
+	}
+	major, arg, rest, err := cborHead(data)
+	if err != nil {
+		return err
+	}
+	var value Fixed
+	switch major {
+	case cborUint, cborNegInt:
+		var v int64
+		if v, rest, err = cborInt(data); err != nil {
+			return err
+		}
+		value, err = FromScaled(v, 0)
+	case cborTag:
+		if arg != cborDecimal {
+			return errFormat
+		}
+		if major, arg, rest, err = cborHead(rest); err != nil || major != cborArray || arg != 2 {
+			return errFormat
+		}
+		var exp, m int64
+		if exp, rest, err = cborInt(rest); err != nil {
+			return err
+		}
+		if m, rest, err = cborInt(rest); err != nil {
+			return err
+		}
+		// limiting the exponent does not change the result
+		if exp > cborMaxExp {
+			exp = cborMaxExp
+		} else if exp < -cborMaxExp {
+			exp = -cborMaxExp
+		}
+		value, err = FromScaled(m, int(exp))
+	case cborSimple:
+		value, err = cborFloat(data[0], arg)
+	default:
+		return errFormat
+	}
+	if err != nil {
+		return err
+	}
+	if len(rest) != 0 {
+		return errFormat
+	}
+	*f = value
+	return nil
+}
+
+// cborFloat returns the Fixed for a NaN or infinite float, with the initial byte b and bits arg
+func cborFloat(b byte, arg uint64) (Fixed, error) {
+	var v float64
+	switch b {
+	case cborHalf:
+		switch {
+		case arg&0x7fff > 0x7c00:
+			v = math.NaN()
+		case arg&0x7fff == 0x7c00:
+			v = math.Inf(1 - int(arg>>15)*2)
+		}
+	case cborSingle:
+		v = float64(math.Float32frombits(uint32(arg)))
+	case cborDouble:
+		v = math.Float64frombits(arg)
+	default:
+		return NaN, errFormat
+	}
+	switch {
+	case math.IsNaN(v):
+		return NaN, nil
+	case math.IsInf(v, 1):
+		return PosInf, nil
+	case math.IsInf(v, -1):
+		return NegInf, nil
+	}
+	return NaN, errFormat
+}
+
+// cborHead decodes the initial byte and argument of a data item, returning the remaining data
+func cborHead(data []byte) (major byte, arg uint64, rest []byte, err error) {
+	if len(data) == 0 {
+		return 0, 0, nil, errFormat
+	}
+	major, info := data[0]>>5, data[0]&0x1f
+	switch {
+	case info < 24:
+		return major, uint64(info), data[1:], nil
+	case info <= 27:
+		n := 1 << (info - 24)
+		if len(data) < 1+n {
+			return 0, 0, nil, errFormat
+		}
+		var b [8]byte
+		copy(b[8-n:], data[1:1+n])
+		return major, binary.BigEndian.Uint64(b[:]), data[1+n:], nil
+	}
+	// reserved, or indefinite length
+	return 0, 0, nil, errFormat
+}
+
+// cborInt decodes an integer, returning ErrOverflow if it does not fit in an int64
+func cborInt(data []byte) (int64, []byte, error) {
+	major, arg, rest, err := cborHead(data)
+	if err != nil || major != cborUint && major != cborNegInt {
+		return 0, nil, errFormat
+	}
+	if arg > math.MaxInt64 {
+		return 0, nil, ErrOverflow
+	}
+	if major == cborNegInt {
+		return -1 - int64(arg), rest, nil
+	}
+	return int64(arg), rest, nil
+}
+
+func cborAppendHead(data []byte, major byte, arg uint64) []byte {
+	switch {
+	case arg < 24:
+		return append(data, major<<5|byte(arg))
+	case arg <= math.MaxUint8:
+		return append(data, major<<5|24, byte(arg))
+	case arg <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(data, major<<5|25), uint16(arg))
+	case arg <= math.MaxUint32:
+		return binary.BigEndian.AppendUint32(append(data, major<<5|26), uint32(arg))
+	}
+	return binary.BigEndian.AppendUint64(append(data, major<<5|27), arg)
+}
+
+func cborAppendInt(data []byte, v int64) []byte {
+	if v < 0 {
+		return cborAppendHead(data, cborNegInt, uint64(-1-v))
+	}
+	return cborAppendHead(data, cborUint, uint64(v))
+}
@@ -0,0 +1,118 @@
+package fixed_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/fxamacker/cbor/v2"
+	. "github.com/robaho/fixed"
+)
+
+func TestCBOR(t *testing.T) {
+	testCases := []struct {
+		in  Fixed
+		out []byte
+	}{
+		{ZERO, []byte{0xc4, 0x82, 0x00, 0x00}},
+		{NewS("1.5"), []byte{0xc4, 0x82, 0x20, 0x0f}},
+		{NewS("-273.15"), []byte{0xc4, 0x82, 0x21, 0x39, 0x6a, 0xb2}},
+		{NewS("1000"), []byte{0xc4, 0x82, 0x00, 0x19, 0x03, 0xe8}},
+		{NewS("0.0000001"), []byte{0xc4, 0x82, 0x26, 0x01}},
+		{NaN, []byte{0xf9, 0x7e, 0x00}},
+		{NaNDivByZero, []byte{0xf9, 0x7e, 0x00}},
+		{PosInf, []byte{0xf9, 0x7c, 0x00}},
+		{NegInf, []byte{0xf9, 0xfc, 0x00}},
+	}
+	for _, tc := range testCases {
+		data, err := tc.in.MarshalCBOR()
+		if err != nil || !bytes.Equal(data, tc.out) {
+			t.Error("wrong encoding", tc.in, data, tc.out, err)
+		}
+		var f Fixed
+		if err := f.UnmarshalCBOR(data); err != nil || f.IsFinite() && !f.Equal(tc.in) || f.IsInf(0) && f != tc.in || tc.in.IsNaN() && !f.IsNaN() {
+			t.Error("should be equal", f, tc.in, err)
+		}
+	}
+	max := NewS("99999999999.9999999")
+	data, _ := max.MarshalCBOR()
+	var f Fixed
+	if err := f.UnmarshalCBOR(data); err != nil || !f.Equal(max) {
+		t.Error("should be equal", f, max, err)
+	}
+}
+
+func TestUnmarshalCBOR(t *testing.T) {
+	testCases := []struct {
+		in  []byte
+		out string
+		err error
+	}{
+		{[]byte{0x18, 0x64}, "100", nil},
+		{[]byte{0x20}, "-1", nil},
+		{[]byte{0xc4, 0x82, 0x02, 0x0f}, "1500", nil},
+		{[]byte{0xc4, 0x82, 0x27, 0x1a, 0x05, 0xf5, 0xe1, 0x00}, "1", nil},
+		{[]byte{0xfb, 0x7f, 0xf8, 0, 0, 0, 0, 0, 0}, "NaN", nil},
+		{[]byte{0xfa, 0xff, 0x80, 0, 0}, "-Inf", nil},
+		{[]byte{0xc4, 0x82, 0x28, 0x01}, "", ErrPrecision},
+		{[]byte{0xc4, 0x82, 0x0b, 0x01}, "", ErrOverflow},
+		{[]byte{0xc4, 0x82, 0x1b, 0x7f, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x01}, "", ErrOverflow},
+		{[]byte{0xc4, 0x82, 0x3b, 0x7f, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x01}, "", ErrPrecision},
+		{[]byte{0x1b, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}, "", ErrOverflow},
+	}
+	for _, tc := range testCases {
+		var f Fixed
+		err := f.UnmarshalCBOR(tc.in)
+		if err != tc.err {
+			t.Error("unexpected error", tc.in, err)
+			continue
+		}
+		if err == nil && f.String() != tc.out {
+			t.Error("should be equal", tc.in, f, tc.out)
+		}
+	}
+	for _, in := range [][]byte{{}, {0xf5}, {0x61, 0x31}, {0xc2, 0x41, 0x01}, {0xc4, 0x83, 0, 0, 0}, {0xc4, 0x82, 0x00},
+		{0x01, 0x02}, {0xfb, 0x3f, 0xf0, 0, 0, 0, 0, 0, 0}, {0xf9, 0x7c}, {0x9f, 0xff}} {
+		var f Fixed
+		if err := f.UnmarshalCBOR(in); err == nil {
+			t.Error("should be an error", in, f)
+		}
+	}
+	f := NewS("1.5")
+	if err := f.UnmarshalCBOR([]byte{0xf6}); err != nil || f.String() != "1.5" {
+		t.Error("null should leave the value unchanged", f, err)
+	}
+}
+
+func TestCBORLibrary(t *testing.T) {
+	type order struct {
+		Price Fixed
+		Qty   Fixed
+	}
+	o := order{Price: NewS("101.25"), Qty: PosInf}
+	data, err := cbor.Marshal(&o)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var o0 order
+	if err := cbor.Unmarshal(data, &o0); err != nil {
+		t.Fatal(err)
+	}
+	if o0.Price.String() != "101.25" || !o0.Qty.IsInf(1) {
+		t.Error("wrong round trip", o0)
+	}
+
+	// the library decodes the tag generically, as [exponent, mantissa]
+	var generic map[string]any
+	if err := cbor.Unmarshal(data, &generic); err != nil {
+		t.Fatal(err)
+	}
+	if tag, ok := generic["Price"].(cbor.Tag); !ok || tag.Number != 4 {
+		t.Error("should be a decimal fraction", generic["Price"])
+	}
+	// and a decimal fraction from another encoder is decoded
+	data, _ = cbor.Marshal(cbor.Tag{Number: 4, Content: []int64{-3, 12345}})
+	var f Fixed
+	if err := cbor.Unmarshal(data, &f); err != nil || f.String() != "12.345" {
+		t.Error("should be equal", f, "12.345", err)
+	}
+}
@@ -0,0 +1,266 @@
+// Command fixedcalc evaluates expressions using the same arithmetic as fixed.Fixed, so a production calculation can
+// be reproduced exactly, including the rounding of Mul and Div and the handling of NaN.
+//
+// Usage:
+//
+//	fixedcalc [flags] [expression]
+//
+// With an expression, it is evaluated and the result printed. Without one, expressions are read from standard input
+// one per line, and a line of the form "name = expression" assigns a variable. The previous result is available as _.
+// See package expr for the syntax.
+//
+// With -csv, a column of a CSV file is aggregated instead, e.g.
+//
+//	fixedcalc -csv trades.csv -col price -agg sum,mean
+//
+// The flags are:
+//
+//	-places n    round results to n decimal places using -mode, and print them with StringN
+//	-mode mode   the rounding mode for -places and means: halfup, halfeven, halfdown, down, up, floor or ceiling
+//	-var n=v     set the variable n to v, and may be repeated
+//	-csv file    aggregate a column of the CSV file, or standard input if file is -
+//	-col c       the column to aggregate, by header name or 1-based index
+//	-header      the first record is a header, which is implied when -col is a name
+//	-agg list    the aggregates to compute, any of sum, mean, min, max, median and count
+//	-skipnan     ignore values which are NaN, including blank cells, when aggregating and counting
+package main
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/robaho/fixed"
+	"github.com/robaho/fixed/expr"
+)
+
+var modes = map[string]fixed.RoundingMode{
+	"halfup":   fixed.RoundHalfUp,
+	"halfeven": fixed.RoundHalfEven,
+	"halfdown": fixed.RoundHalfDown,
+	"down":     fixed.RoundDown,
+	"up":       fixed.RoundUp,
+	"floor":    fixed.RoundFloor,
+	"ceiling":  fixed.RoundCeiling,
+}
+
+// varsFlag collects -var flags
+type varsFlag expr.Vars
+
+func (v varsFlag) String() string {
+	return ""
+}
+
+func (v varsFlag) Set(s string) error {
+	name, value, ok := strings.Cut(s, "=")
+	if !ok {
+		return errors.New("expected name=value")
+	}
+	f, err := fixed.NewSErr(strings.TrimSpace(value))
+	if err != nil {
+		return err
+	}
+	v[strings.TrimSpace(name)] = f
+	return nil
+}
+
+type calc struct {
+	places int
+	mode   fixed.RoundingMode
+	vars   expr.Vars
+	out    io.Writer
+}
+
+// format returns f rounded and formatted according to -places
+func (c *calc) format(f fixed.Fixed) string {
+	if c.places < 0 {
+		return f.String()
+	}
+	return f.RoundTo(c.places, c.mode).StringN(c.places)
+}
+
+func (c *calc) eval(s string) (fixed.Fixed, error) {
+	e, err := expr.Compile(s)
+	if err != nil {
+		return fixed.NaN, err
+	}
+	return e.Eval(c.vars)
+}
+
+// splitAssignment splits "name = expression" into the name and expression. If line is not an assignment, the name
+// is empty
+func splitAssignment(line string) (name, s string) {
+	i := strings.IndexByte(line, '=')
+	if i < 0 || strings.HasPrefix(line[i:], "==") {
+		return "", line
+	}
+	name = strings.TrimSpace(line[:i])
+	if name == "" || strings.IndexFunc(name, func(r rune) bool {
+		return !(r == '_' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9')
+	}) >= 0 || name[0] >= '0' && name[0] <= '9' {
+		return "", line
+	}
+	return name, line[i+1:]
+}
+
+// repl evaluates each line of in, reporting errors without stopping
+func (c *calc) repl(in io.Reader, errOut io.Writer) error {
+	scanner := bufio.NewScanner(in)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		name, s := splitAssignment(line)
+		f, err := c.eval(s)
+		if err != nil {
+			fmt.Fprintln(errOut, err)
+			continue
+		}
+		if name != "" {
+			c.vars[name] = f
+		}
+		c.vars["_"] = f
+		fmt.Fprintln(c.out, c.format(f))
+	}
+	return scanner.Err()
+}
+
+// aggregate computes the aggregates of a column of CSV data. A blank cell is NaN
+func (c *calc) aggregate(in io.Reader, col string, header bool, aggs []string, skipNaN bool) error {
+	r := csv.NewReader(in)
+	r.FieldsPerRecord = -1
+	records, err := r.ReadAll()
+	if err != nil {
+		return err
+	}
+	if len(records) == 0 {
+		return errors.New("no data")
+	}
+	index, err := strconv.Atoi(col)
+	if err == nil {
+		index--
+	} else {
+		index = -1
+		for i, name := range records[0] {
+			if strings.TrimSpace(name) == col {
+				index = i
+			}
+		}
+		if index < 0 {
+			return fmt.Errorf("no column %q", col)
+		}
+		header = true
+	}
+	if header {
+		records = records[1:]
+	}
+	values := make([]fixed.Fixed, 0, len(records))
+	for i, record := range records {
+		if index < 0 || index >= len(record) {
+			return fmt.Errorf("record %d: no column %s", i+1, col)
+		}
+		f := fixed.NaN
+		if s := strings.TrimSpace(record[index]); s != "" {
+			if f, err = fixed.NewSErr(s); err != nil {
+				if i == 0 && !header {
+					return fmt.Errorf("record 1: %w, use -header to skip a header record", err)
+				}
+				return fmt.Errorf("record %d: %w", i+1, err)
+			}
+		}
+		// the values skipped are not counted either
+		if skipNaN && f.IsNaN() {
+			continue
+		}
+		values = append(values, f)
+	}
+
+	a := fixed.Aggregator{SkipNaN: skipNaN, Mode: c.mode}
+	for _, agg := range aggs {
+		var f fixed.Fixed
+		switch agg {
+		case "sum":
+			f = a.Sum(values)
+		case "mean":
+			f = a.Mean(values)
+		case "min":
+			f = a.Min(values)
+		case "max":
+			f = a.Max(values)
+		case "median":
+			f = a.Median(values)
+		case "count":
+			f = fixed.FromInt(int64(len(values)))
+		default:
+			return fmt.Errorf("unknown aggregate %q", agg)
+		}
+		if len(aggs) == 1 {
+			fmt.Fprintln(c.out, c.format(f))
+		} else {
+			fmt.Fprintf(c.out, "%s\t%s\n", agg, c.format(f))
+		}
+	}
+	return nil
+}
+
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("fixedcalc", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	vars := varsFlag{}
+	places := flags.Int("places", -1, "round results to `n` decimal places")
+	mode := flags.String("mode", "halfup", "rounding `mode` for -places and means")
+	flags.Var(vars, "var", "set a variable, as `name=value`")
+	csvFile := flags.String("csv", "", "aggregate a column of the CSV `file`, - for standard input")
+	col := flags.String("col", "1", "the `column` to aggregate, by header name or 1-based index")
+	header := flags.Bool("header", false, "the first CSV record is a header")
+	aggs := flags.String("agg", "sum", "comma separated `list` of sum, mean, min, max, median and count")
+	skipNaN := flags.Bool("skipnan", false, "ignore NaN values when aggregating")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	m, ok := modes[*mode]
+	if !ok {
+		fmt.Fprintf(stderr, "unknown rounding mode %q\n", *mode)
+		return 2
+	}
+	c := &calc{places: min(*places, 7), mode: m, vars: expr.Vars(vars), out: stdout}
+
+	var err error
+	switch {
+	case *csvFile != "":
+		in := stdin
+		if *csvFile != "-" {
+			file, ferr := os.Open(*csvFile)
+			if ferr != nil {
+				fmt.Fprintln(stderr, ferr)
+				return 1
+			}
+			defer file.Close()
+			in = file
+		}
+		err = c.aggregate(in, *col, *header, strings.Split(*aggs, ","), *skipNaN)
+	case flags.NArg() > 0:
+		var f fixed.Fixed
+		if f, err = c.eval(strings.Join(flags.Args(), " ")); err == nil {
+			fmt.Fprintln(stdout, c.format(f))
+		}
+	default:
+		err = c.repl(stdin, stderr)
+	}
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+	return 0
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func runCalc(t *testing.T, stdin string, args ...string) (string, string, int) {
+	t.Helper()
+	var stdout, stderr bytes.Buffer
+	code := run(args, strings.NewReader(stdin), &stdout, &stderr)
+	return stdout.String(), stderr.String(), code
+}
+
+func TestOneShot(t *testing.T) {
+	testCases := []struct {
+		args []string
+		out  string
+	}{
+		{[]string{"1.5 * 3"}, "4.5\n"},
+		{[]string{"1", "+", "2"}, "3\n"},
+		// the rounding of Mul is reproduced
+		{[]string{"0.0000001 * 0.5"}, "0.0000001\n"},
+		{[]string{"1 / 0"}, "NaN\n"},
+		{[]string{"-places", "2", "10 / 3"}, "3.33\n"},
+		{[]string{"-places", "2", "-mode", "up", "10 / 3"}, "3.34\n"},
+		{[]string{"-places", "0", "-mode", "halfeven", "2.5"}, "2\n"},
+		{[]string{"-var", "qty=1000", "-var", "notional=250000", "notional * 0.0005 + max(1.50, qty * 0.002)"}, "127\n"},
+	}
+	for _, tc := range testCases {
+		out, errOut, code := runCalc(t, "", tc.args...)
+		if out != tc.out || code != 0 {
+			t.Error("wrong result", tc.args, out, errOut, code)
+		}
+	}
+
+	if _, errOut, code := runCalc(t, "", "1 +"); code != 1 || !strings.Contains(errOut, "syntax error") {
+		t.Error("should fail", errOut, code)
+	}
+	if _, _, code := runCalc(t, "", "-mode", "sideways", "1"); code != 2 {
+		t.Error("should fail", code)
+	}
+}
+
+func TestREPL(t *testing.T) {
+	out, errOut, code := runCalc(t, `
+x = 10 / 4
+# comment
+x * 2
+_ + 1
+bad +
+x == 2.5
+y = x >= 2
+`)
+	if code != 0 || out != "2.5\n5\n6\n1\n1\n" {
+		t.Error("wrong output", out, code)
+	}
+	if !strings.Contains(errOut, "syntax error") {
+		t.Error("should report the error", errOut)
+	}
+}
+
+func TestCSV(t *testing.T) {
+	data := "sym,price,qty\nA,10.5,100\nB,11.25,200\nC,9.75,300\n"
+	out, errOut, code := runCalc(t, data, "-csv", "-", "-col", "price", "-agg", "sum,mean,min,max,median,count")
+	if code != 0 || out != "sum\t31.5\nmean\t10.5\nmin\t9.75\nmax\t11.25\nmedian\t10.5\ncount\t3\n" {
+		t.Error("wrong output", out, errOut, code)
+	}
+	out, _, code = runCalc(t, "1\n2\n2\n", "-csv", "-", "-agg", "mean", "-places", "2")
+	if code != 0 || out != "1.67\n" {
+		t.Error("wrong output", out, code)
+	}
+	out, _, code = runCalc(t, "1\nNaN\n2\n", "-csv", "-", "-agg", "sum", "-skipnan")
+	if code != 0 || out != "3\n" {
+		t.Error("wrong output", out, code)
+	}
+	if _, errOut, code := runCalc(t, data, "-csv", "-", "-col", "volume"); code != 1 || !strings.Contains(errOut, "no column") {
+		t.Error("should fail", errOut, code)
+	}
+	if _, errOut, code := runCalc(t, "1\nx\n", "-csv", "-"); code != 1 || !strings.Contains(errOut, "record 2") {
+		t.Error("should fail", errOut, code)
+	}
+	out, _, code = runCalc(t, data, "-csv", "-", "-col", "2", "-header", "-agg", "sum")
+	if code != 0 || out != "31.5\n" {
+		t.Error("wrong output", out, code)
+	}
+	if _, errOut, code := runCalc(t, data, "-csv", "-", "-col", "2"); code != 1 || !strings.Contains(errOut, "-header") {
+		t.Error("should fail", errOut, code)
+	}
+	blank := "price\n1\n \n2\n"
+	out, _, code = runCalc(t, blank, "-csv", "-", "-header", "-agg", "sum")
+	if code != 0 || out != "NaN\n" {
+		t.Error("wrong output", out, code)
+	}
+	out, _, code = runCalc(t, blank, "-csv", "-", "-col", "price", "-agg", "sum", "-skipnan")
+	if code != 0 || out != "3\n" {
+		t.Error("wrong output", out, code)
+	}
+	out, _, code = runCalc(t, "price,q\n1,a\n,b\n3,c\nNaN,d\n", "-csv", "-", "-col", "price", "-agg", "count,sum,mean", "-skipnan")
+	if code != 0 || out != "count\t2\nsum\t4\nmean\t2\n" {
+		t.Error("wrong output", out, code)
+	}
+	out, _, code = runCalc(t, "price,q\n1,a\n,b\n3,c\n", "-csv", "-", "-col", "price", "-agg", "count")
+	if code != 0 || out != "3\n" {
+		t.Error("wrong output", out, code)
+	}
+}
@@ -0,0 +1,61 @@
+package fixed
+
+import "slices"
+
+// Compare returns -1, 0 or 1 as a is less than, equal to or greater than b, ordering -Inf before all finite values,
+// and NaN of any kind after +Inf. All NaN compare equal. It is the same as a.Cmp(b), and is a total order suitable
+// for slices.SortFunc and the cmp package conventions
+func Compare(a, b Fixed) int {
+	return a.Cmp(b)
+}
+
+// EqualNaN returns true if f and f0 are equal, treating all NaN as equal to each other. Unlike Equal, it is
+// consistent with Compare, i.e. it is true exactly when Compare returns 0, so it can be used to deduplicate values
+func (f Fixed) EqualNaN(f0 Fixed) bool {
+	return f.Cmp(f0) == 0
+}
+
+// SortSlice sorts the values in ascending order using Compare
+func SortSlice(values []Fixed) {
+	slices.SortFunc(values, Compare)
+}
+
+// BinarySearch searches for target in values, which must be sorted by Compare. It returns the position where target
+// is found, or would be inserted, and whether it was found
+func BinarySearch(values []Fixed, target Fixed) (int, bool) {
+	return slices.BinarySearchFunc(values, target, Compare)
+}
+
+// MinOf returns the smallest of the values using Compare, so NaN is only returned if all values are NaN
+func MinOf(f Fixed, others ...Fixed) Fixed {
+	for _, f0 := range others {
+		if f0.Cmp(f) < 0 {
+			f = f0
+		}
+	}
+	return f
+}
+
+// MaxOf returns the largest of the values using Compare, so NaN is returned if any value is NaN
+func MaxOf(f Fixed, others ...Fixed) Fixed {
+	for _, f0 := range others {
+		if f0.Cmp(f) > 0 {
+			f = f0
+		}
+	}
+	return f
+}
+
+// Clamp returns f limited to the range [lo,hi]. If f is NaN, it is returned unchanged
+func Clamp(f, lo, hi Fixed) Fixed {
+	if f.IsNaN() {
+		return f
+	}
+	if f.Cmp(lo) < 0 {
+		return lo
+	}
+	if f.Cmp(hi) > 0 {
+		return hi
+	}
+	return f
+}
@@ -0,0 +1,102 @@
+package fixed_test
+
+import (
+	"slices"
+	"testing"
+
+	. "github.com/robaho/fixed"
+)
+
+func TestCompareConsistency(t *testing.T) {
+	values := []Fixed{NaN, PosInf, NegInf, NaNDivByZero, ZERO, NewS("-1.5"), NewS("1.5"), NewS("1.5")}
+	for _, a := range values {
+		for _, b := range values {
+			c := Compare(a, b)
+			if c != -Compare(b, a) {
+				t.Error("should be antisymmetric", a, b)
+			}
+			if (c == 0) != a.EqualNaN(b) {
+				t.Error("EqualNaN should be consistent with Compare", a, b)
+			}
+			if !a.IsNaN() && !b.IsNaN() && a.EqualNaN(b) != a.Equal(b) {
+				t.Error("EqualNaN should be Equal for numbers", a, b)
+			}
+			for _, c2 := range values {
+				if Compare(a, b) <= 0 && Compare(b, c2) <= 0 && Compare(a, c2) > 0 {
+					t.Error("should be transitive", a, b, c2)
+				}
+			}
+		}
+	}
+	if !NaN.EqualNaN(NaN) || NaN.Equal(NaN) {
+		t.Error("wrong NaN equality")
+	}
+}
+
+func TestSortSlice(t *testing.T) {
+	values := []Fixed{NaN, NewS("3"), PosInf, NewS("-1"), NegInf, NewS("2"), NaN}
+	SortSlice(values)
+	want := []string{"-Inf", "-1", "2", "3", "+Inf", "NaN", "NaN"}
+	for i, f := range values {
+		if f.String() != want[i] {
+			t.Error("should be equal", i, f, want[i])
+		}
+	}
+	if !slices.IsSortedFunc(values, Compare) {
+		t.Error("should be sorted")
+	}
+	// deduplicate, including NaN
+	values = slices.CompactFunc(values, Fixed.EqualNaN)
+	if len(values) != 6 {
+		t.Error("wrong length", values)
+	}
+
+	i, found := BinarySearch(values, NewS("2"))
+	if !found || i != 2 {
+		t.Error("should be found", i, found)
+	}
+	i, found = BinarySearch(values, NewS("2.5"))
+	if found || i != 3 {
+		t.Error("should not be found", i, found)
+	}
+	i, found = BinarySearch(values, NaN)
+	if !found || i != 5 {
+		t.Error("should be found", i, found)
+	}
+}
+
+func TestMinMaxClamp(t *testing.T) {
+	if f := MinOf(NewS("3"), NaN, NewS("-2"), NewS("1")); f.String() != "-2" {
+		t.Error("should be equal", f, "-2")
+	}
+	if f := MinOf(NaN, NaN); !f.IsNaN() {
+		t.Error("should be NaN", f)
+	}
+	if f := MaxOf(NewS("3"), NewS("-2")); f.String() != "3" {
+		t.Error("should be equal", f, "3")
+	}
+	if f := MaxOf(NewS("3"), NaN); !f.IsNaN() {
+		t.Error("should be NaN", f)
+	}
+	if f := MaxOf(NewS("3")); f.String() != "3" {
+		t.Error("should be equal", f, "3")
+	}
+
+	lo, hi := NewS("1"), NewS("2")
+	testCases := []struct {
+		in  Fixed
+		out string
+	}{
+		{NewS("0.5"), "1"},
+		{NewS("1.5"), "1.5"},
+		{NewS("2.5"), "2"},
+		{NegInf, "1"},
+		{PosInf, "2"},
+		{NaN, "NaN"},
+	}
+	for _, tc := range testCases {
+		if f := Clamp(tc.in, lo, hi); f.String() != tc.out {
+			t.Error("should be equal", tc.in, f, tc.out)
+		}
+	}
+}
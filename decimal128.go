@@ -0,0 +1,123 @@
+package fixed
+
+import (
+	"math"
+	"math/bits"
+)
+
+// IEEE 754-2008 decimal128, with the binary integer decimal (BID) encoding of the coefficient used by BSON. Every
+// finite Fixed is exactly representable. The kind of a NaN is carried in its payload.
+
+const (
+	d128Bias = 6176
+	d128Sign = 1 << 63
+	d128Inf  = 0x78 << 56
+	d128NaN  = 0x7c << 56
+	// 10^34, one more than the largest coefficient, as its high and low 64 bits
+	d128MaxHi = 0x1ed09bead87c0
+	d128MaxLo = 0x378d8e6400000000
+)
+
+// trimZeros returns the coefficient and exponent of f with the trailing zeros of the fraction removed, e.g. 1.5 is 15
+// and -1, and 1000 is 1000 and 0
+func (f Fixed) trimZeros() (int64, int) {
+	if f.fp == 0 {
+		return 0, 0
+	}
+	m, exp := f.fp, -nPlaces
+	for m%10 == 0 && exp < 0 {
+		m /= 10
+		exp++
+	}
+	return m, exp
+}
+
+// nanPayload returns the payload that records the kind of the NaN f, 0 for a plain NaN
+func (f Fixed) nanPayload() uint64 {
+	if f.fp > posInf {
+		return uint64(nan - f.fp)
+	}
+	return 0
+}
+
+// nanOfPayload returns the NaN with the kind recorded by payload, or NaN if it is not a known kind
+func nanOfPayload(payload uint64) Fixed {
+	if payload < uint64(nan-posInf) {
+		return Fixed{fp: nan - int64(payload)}
+	}
+	return NaN
+}
+
+// toBID128 returns the high and low 64 bits of the decimal128 encoding of f, with the coefficient and exponent from
+// trimZeros
+func (f Fixed) toBID128() (hi, lo uint64) {
+	switch {
+	case f.IsNaN():
+		return d128NaN, f.nanPayload()
+	case f.IsInf(1):
+		return d128Inf, 0
+	case f.IsInf(-1):
+		return d128Sign | d128Inf, 0
+	}
+	m, exp := f.trimZeros()
+	if m < 0 {
+		hi = d128Sign
+	}
+	return hi | uint64(exp+d128Bias)<<49, uabs(m)
+}
+
+// fromBID128 returns the Fixed for the decimal128 with high and low 64 bits hi and lo. ErrOverflow is returned if
+// the value is out of range, and ErrPrecision if it has more than 7 decimal places
+func fromBID128(hi, lo uint64) (Fixed, error) {
+	neg := hi&d128Sign != 0
+	switch {
+	case hi&d128NaN == d128NaN:
+		return nanOfPayload(lo), nil
+	case hi&d128NaN == d128Inf:
+		if neg {
+			return NegInf, nil
+		}
+		return PosInf, nil
+	}
+	var exp int
+	if hi>>61&3 == 3 {
+		// the coefficient is at least 2^113, which is larger than the maximum, so it is non-canonical and zero
+		exp = int(hi>>47&0x3fff) - d128Bias
+		hi, lo = 0, 0
+	} else {
+		exp = int(hi>>49&0x3fff) - d128Bias
+		hi &= 1<<49 - 1
+		if hi > d128MaxHi || hi == d128MaxHi && lo >= d128MaxLo {
+			hi, lo = 0, 0
+		}
+	}
+	return fromCoefficient(neg, hi, lo, exp)
+}
+
+// fromCoefficient returns the Fixed with the value (hi*2^64+lo)*10^exp, negated if neg. ErrOverflow is returned if
+// the value is out of range, and ErrPrecision if it has more than 7 decimal places
+func fromCoefficient(neg bool, hi, lo uint64, exp int) (Fixed, error) {
+	if hi == 0 && lo == 0 {
+		return ZERO, nil
+	}
+	// remove trailing zeros while the coefficient or number of places is too large
+	for hi != 0 || lo > math.MaxInt64 || exp < -nPlaces {
+		q, r := bits.Div64(hi%10, lo, 10)
+		if r != 0 {
+			break
+		}
+		hi, lo = hi/10, q
+		exp++
+	}
+	if hi != 0 || lo > math.MaxInt64 {
+		if exp < -nPlaces {
+			return NaN, ErrPrecision
+		}
+		return NaNOverflow, ErrOverflow
+	}
+	v := int64(lo)
+	if neg {
+		v = -v
+	}
+	return FromScaled(v, exp)
+}
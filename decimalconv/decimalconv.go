@@ -0,0 +1,82 @@
+// Package decimalconv converts between fixed.Fixed and shopspring decimal.Decimal, to allow code to be migrated
+// incrementally. Conversions are exact, and return an error rather than lose precision or range.
+package decimalconv
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/robaho/fixed"
+	"github.com/shopspring/decimal"
+)
+
+const places = 7
+
+var bigTen = big.NewInt(10)
+
+// FromDecimal converts d to a Fixed. fixed.ErrPrecision is returned if d has more than 7 significant decimal places,
+// and fixed.ErrOverflow if it is out of range
+func FromDecimal(d decimal.Decimal) (fixed.Fixed, error) {
+	c := d.Coefficient()
+	if c.Sign() == 0 {
+		return fixed.ZERO, nil
+	}
+	exp := int(d.Exponent()) + places
+	switch {
+	case exp > 18:
+		// at least 19 digits, and the limit is 18
+		return fixed.NaNOverflow, fixed.ErrOverflow
+	case exp >= 0:
+		c.Mul(c, new(big.Int).Exp(bigTen, big.NewInt(int64(exp)), nil))
+	case -exp > c.BitLen():
+		// 10^-exp is greater than c, so it cannot divide it
+		return fixed.NaN, fixed.ErrPrecision
+	default:
+		var r big.Int
+		c.QuoRem(c, new(big.Int).Exp(bigTen, big.NewInt(int64(-exp)), nil), &r)
+		if r.Sign() != 0 {
+			return fixed.NaN, fixed.ErrPrecision
+		}
+	}
+	return fixed.FromBigInt(c)
+}
+
+// ToDecimal converts f to a Decimal. Decimal cannot represent NaN or infinities, so fixed.ErrNaN is returned if f is
+// NaN, and fixed.ErrOverflow if it is infinite
+func ToDecimal(f fixed.Fixed) (decimal.Decimal, error) {
+	if f.IsNaN() {
+		return decimal.Zero, fixed.ErrNaN
+	}
+	if f.IsInf(0) {
+		return decimal.Zero, fixed.ErrOverflow
+	}
+	return decimal.New(f.Raw(), -places), nil
+}
+
+// FromDecimals converts the values using FromDecimal. If any conversion fails, the error identifies the first
+// failing index and wraps the cause
+func FromDecimals(ds []decimal.Decimal) ([]fixed.Fixed, error) {
+	fs := make([]fixed.Fixed, len(ds))
+	for i, d := range ds {
+		f, err := FromDecimal(d)
+		if err != nil {
+			return nil, fmt.Errorf("decimalconv: index %d: %w", i, err)
+		}
+		fs[i] = f
+	}
+	return fs, nil
+}
+
+// ToDecimals converts the values using ToDecimal. If any conversion fails, the error identifies the first failing
+// index and wraps the cause
+func ToDecimals(fs []fixed.Fixed) ([]decimal.Decimal, error) {
+	ds := make([]decimal.Decimal, len(fs))
+	for i, f := range fs {
+		d, err := ToDecimal(f)
+		if err != nil {
+			return nil, fmt.Errorf("decimalconv: index %d: %w", i, err)
+		}
+		ds[i] = d
+	}
+	return ds, nil
+}
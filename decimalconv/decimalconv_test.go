@@ -0,0 +1,77 @@
+package decimalconv
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/robaho/fixed"
+	"github.com/shopspring/decimal"
+)
+
+func TestFromDecimal(t *testing.T) {
+	testCases := []struct {
+		in  string
+		out string
+		err error
+	}{
+		{"123.456", "123.456", nil},
+		{"-0.0000001", "-0.0000001", nil},
+		{"0", "0", nil},
+		{"1.23000000000", "1.23", nil},
+		{"99999999999.9999999", "99999999999.9999999", nil},
+		{"0.00000001", "", fixed.ErrPrecision},
+		{"1e-300", "", fixed.ErrPrecision},
+		{"100000000000", "", fixed.ErrOverflow},
+		{"1e300", "", fixed.ErrOverflow},
+		{"0e300", "0", nil},
+	}
+	for _, tc := range testCases {
+		f, err := FromDecimal(decimal.RequireFromString(tc.in))
+		if err != tc.err {
+			t.Error("unexpected error", tc.in, err)
+			continue
+		}
+		if err == nil && f.String() != tc.out {
+			t.Error("should be equal", tc.in, f, tc.out)
+		}
+	}
+	// a large coefficient with a matching exponent
+	f, err := FromDecimal(decimal.New(1e18, -18))
+	if err != nil || f.String() != "1" {
+		t.Error("should be equal", f, err, "1")
+	}
+}
+
+func TestToDecimal(t *testing.T) {
+	for _, s := range []string{"123.456", "-0.0000001", "0", "99999999999.9999999"} {
+		d, err := ToDecimal(fixed.NewS(s))
+		if err != nil || !d.Equal(decimal.RequireFromString(s)) {
+			t.Error("should be equal", d, err, s)
+		}
+		f, err := FromDecimal(d)
+		if err != nil || f.String() != s {
+			t.Error("should round trip", f, err, s)
+		}
+	}
+	if _, err := ToDecimal(fixed.NaN); err != fixed.ErrNaN {
+		t.Error("should be ErrNaN", err)
+	}
+}
+
+func TestSlices(t *testing.T) {
+	fs, err := FromDecimals([]decimal.Decimal{decimal.New(15, -1), decimal.New(-2, 0)})
+	if err != nil || len(fs) != 2 || fs[0].String() != "1.5" || fs[1].String() != "-2" {
+		t.Error("wrong result", fs, err)
+	}
+	_, err = FromDecimals([]decimal.Decimal{decimal.New(1, 0), decimal.New(1, -8)})
+	if !errors.Is(err, fixed.ErrPrecision) || err.Error() != "decimalconv: index 1: value cannot be represented exactly" {
+		t.Error("wrong error", err)
+	}
+	ds, err := ToDecimals(fs)
+	if err != nil || len(ds) != 2 || ds[0].String() != "1.5" || ds[1].String() != "-2" {
+		t.Error("wrong result", ds, err)
+	}
+	if _, err := ToDecimals([]fixed.Fixed{fixed.ZERO, fixed.NaN}); !errors.Is(err, fixed.ErrNaN) {
+		t.Error("wrong error", err)
+	}
+}
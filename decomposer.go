@@ -14,12 +14,18 @@ import (
 
 // Decompose returns the internal decimal state into parts.
 // If the provided buf has sufficient capacity, buf may be returned as the coefficient with
-// the value set and length set as appropriate.
+// the value set and length set as appropriate. Infinities use the infinite form, and NaN of
+// any kind the NaN form.
 func (f Fixed) Decompose(buf []byte) (form byte, negative bool, coefficient []byte, exponent int32) {
-	if f.fp == nan {
+	if f.IsNaN() {
 		form = 2
 		return
 	}
+	if f.IsInf(0) {
+		form = 1
+		negative = f.fp < 0
+		return
+	}
 	if f.fp == 0 {
 		return
 	}
@@ -50,8 +56,11 @@ func (f *Fixed) Compose(form byte, negative bool, coefficient []byte, exponent i
 	case 0:
 		// Finite form, see below.
 	case 1:
-		// Infinite form, turn into NaN.
-		f.fp = nan
+		if negative {
+			*f = NegInf
+		} else {
+			*f = PosInf
+		}
 		return nil
 	case 2:
 		f.fp = nan
@@ -16,11 +16,13 @@ func TestDecomposerRoundTrip(t *testing.T) {
 		{N: "Zero", S: "0"},
 		{N: "Normal-1", S: "123.456"},
 		{N: "Normal-2", S: "-123.456"},
+		{N: "PosInf", S: "+Inf"},
+		{N: "NegInf", S: "-Inf"},
 	}
 	for _, item := range list {
 		t.Run(item.N, func(t *testing.T) {
 			d := NewS(item.S)
-			if d.IsNaN() {
+			if !d.IsFinite() && !d.IsInf(0) {
 				t.Fatal("failed to parse number")
 			}
 			set := &Fixed{}
@@ -60,6 +62,8 @@ func TestDecomposerCompose(t *testing.T) {
 		{N: "TooSmall-1", S: "-0.00123456", Neg: true, Coef: []byte{0x01, 0xE2, 0x40}, Exp: -8, Err: true},
 		{N: "LeadingZero-1", S: "123.456", Coef: []byte{0, 0, 0, 0, 0, 0, 0, 0x01, 0xE2, 0x40}, Exp: -3},
 		{N: "NaN-1", S: "NaN", Form: 2},
+		{N: "Inf-1", S: "+Inf", Form: 1},
+		{N: "Inf-2", S: "-Inf", Form: 1, Neg: true},
 	}
 
 	for _, item := range list {
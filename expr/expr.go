@@ -0,0 +1,218 @@
+// Package expr parses and evaluates arithmetic expressions over fixed.Fixed, such as fee schedules and commission
+// formulas configured as strings, e.g. "notional * 0.0005 + max(1.50, qty * 0.002)".
+//
+// An expression is made of decimal numbers, variables, the operators + - * / with the usual precedence, parentheses,
+// the comparisons < <= > >= == != which evaluate to 1 or 0, and the functions:
+//
+//	min(x, ...)   the smallest of the arguments
+//	max(x, ...)   the largest of the arguments
+//	abs(x)        the absolute value of x
+//	round(x[, n]) x rounded half-up to n decimal places, default 0
+//	floor(x[, n]) x rounded towards negative infinity to n decimal places, default 0
+//	ceil(x[, n])  x rounded towards positive infinity to n decimal places, default 0
+//
+// The operators are evaluated using the corresponding Fixed methods, so / is Div, and NaN and infinities propagate
+// as they do for Fixed. Comparisons follow Cmp, except that == and != follow Equal. A compiled Expr is immutable,
+// safe for concurrent use, and evaluating it does not allocate.
+package expr
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/robaho/fixed"
+)
+
+var ErrSyntax = errors.New("expr: syntax error")
+var ErrUnknownVariable = errors.New("expr: unknown variable")
+
+// Vars holds the values of the variables used by an expression
+type Vars map[string]fixed.Fixed
+
+// Expr is a compiled expression
+type Expr struct {
+	src  string
+	root node
+	vars []string
+}
+
+// Compile parses an expression
+func Compile(s string) (*Expr, error) {
+	p := parser{lexer: lexer{src: s}, vars: map[string]bool{}}
+	p.next()
+	root, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+	if p.tok.kind != tokEOF {
+		return nil, p.errorf("unexpected %q", p.tok.text)
+	}
+	vars := make([]string, 0, len(p.vars))
+	for name := range p.vars {
+		vars = append(vars, name)
+	}
+	sort.Strings(vars)
+	return &Expr{src: s, root: root, vars: vars}, nil
+}
+
+// MustCompile is Compile, but panics if the expression cannot be parsed
+func MustCompile(s string) *Expr {
+	e, err := Compile(s)
+	if err != nil {
+		panic(err)
+	}
+	return e
+}
+
+// Eval evaluates the expression using the values of the variables in vars. An error wrapping ErrUnknownVariable is
+// returned if a variable is missing
+func (e *Expr) Eval(vars Vars) (fixed.Fixed, error) {
+	return e.root.eval(vars)
+}
+
+// Vars returns the names of the variables used by the expression, in sorted order
+func (e *Expr) Vars() []string {
+	return append([]string(nil), e.vars...)
+}
+
+// String returns the source of the expression
+func (e *Expr) String() string {
+	return e.src
+}
+
+type node interface {
+	eval(vars Vars) (fixed.Fixed, error)
+}
+
+type constant struct {
+	value fixed.Fixed
+}
+
+func (n constant) eval(Vars) (fixed.Fixed, error) {
+	return n.value, nil
+}
+
+type variable struct {
+	name string
+}
+
+func (n variable) eval(vars Vars) (fixed.Fixed, error) {
+	f, ok := vars[n.name]
+	if !ok {
+		return fixed.NaN, fmt.Errorf("%w %q", ErrUnknownVariable, n.name)
+	}
+	return f, nil
+}
+
+type negate struct {
+	x node
+}
+
+func (n negate) eval(vars Vars) (fixed.Fixed, error) {
+	x, err := n.x.eval(vars)
+	return fixed.ZERO.Sub(x), err
+}
+
+var one = fixed.NewI(1, 0)
+
+type binary struct {
+	op   tokenKind
+	x, y node
+}
+
+func (n binary) eval(vars Vars) (fixed.Fixed, error) {
+	x, err := n.x.eval(vars)
+	if err != nil {
+		return fixed.NaN, err
+	}
+	y, err := n.y.eval(vars)
+	if err != nil {
+		return fixed.NaN, err
+	}
+	var b bool
+	switch n.op {
+	case tokAdd:
+		return x.Add(y), nil
+	case tokSub:
+		return x.Sub(y), nil
+	case tokMul:
+		return x.Mul(y), nil
+	case tokDiv:
+		return x.Div(y), nil
+	case tokLT:
+		b = x.LessThan(y)
+	case tokLE:
+		b = x.LessThanOrEqual(y)
+	case tokGT:
+		b = x.GreaterThan(y)
+	case tokGE:
+		b = x.GreaterThanOrEqual(y)
+	case tokEQ:
+		b = x.Equal(y)
+	case tokNE:
+		b = !x.Equal(y)
+	}
+	if b {
+		return one, nil
+	}
+	return fixed.ZERO, nil
+}
+
+// extreme is min or max. A NaN argument is returned rather than ordered
+type extreme struct {
+	max  bool
+	args []node
+}
+
+func (n extreme) eval(vars Vars) (fixed.Fixed, error) {
+	var result fixed.Fixed
+	for i, arg := range n.args {
+		x, err := arg.eval(vars)
+		if err != nil {
+			return fixed.NaN, err
+		}
+		if x.IsNaN() {
+			return x, nil
+		}
+		if i == 0 || (n.max && x.GreaterThan(result)) || (!n.max && x.LessThan(result)) {
+			result = x
+		}
+	}
+	return result, nil
+}
+
+type absolute struct {
+	x node
+}
+
+func (n absolute) eval(vars Vars) (fixed.Fixed, error) {
+	x, err := n.x.eval(vars)
+	return x.Abs(), err
+}
+
+// rounding is round, floor or ceil. places is nil for the default of 0
+type rounding struct {
+	mode   fixed.RoundingMode
+	x      node
+	places node
+}
+
+func (n rounding) eval(vars Vars) (fixed.Fixed, error) {
+	x, err := n.x.eval(vars)
+	if err != nil {
+		return fixed.NaN, err
+	}
+	var places int64
+	if n.places != nil {
+		p, err := n.places.eval(vars)
+		if err != nil {
+			return fixed.NaN, err
+		}
+		var ok bool
+		if places, ok = p.ToInt64(fixed.RoundDown); !ok {
+			return fixed.NaN, nil
+		}
+	}
+	return x.RoundTo(int(places), n.mode), nil
+}
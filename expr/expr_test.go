@@ -0,0 +1,126 @@
+package expr
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/robaho/fixed"
+)
+
+func TestEval(t *testing.T) {
+	vars := Vars{
+		"notional": fixed.NewS("250000"),
+		"qty":      fixed.NewS("1000"),
+		"price":    fixed.NewS("12.3456"),
+		"zero":     fixed.ZERO,
+	}
+	testCases := []struct {
+		expr string
+		out  string
+	}{
+		{"notional * 0.0005 + max(1.50, qty * 0.002)", "127"},
+		{"1 + 2 * 3", "7"},
+		{"(1 + 2) * 3", "9"},
+		{"10 - 4 - 3", "3"},
+		{"-2 * -3", "6"},
+		{"+5 - -5", "10"},
+		{"7 / 2", "3.5"},
+		{"1 + 2 > 2", "1"},
+		{"qty <= 999", "0"},
+		{"price == 12.3456", "1"},
+		{"price != 12.3456", "0"},
+		{"(qty >= 1000) * 5 + (qty < 1000) * 10", "5"},
+		{"min(3, price, 20)", "3"},
+		{"max(3, price, 20)", "20"},
+		{"min(4)", "4"},
+		{"abs(-2.5)", "2.5"},
+		{"round(price)", "12"},
+		{"round(price, 2)", "12.35"},
+		{"round(-2.5)", "-3"},
+		{"floor(price, 1)", "12.3"},
+		{"floor(-price, 1)", "-12.4"},
+		{"ceil(price, 1 + 1)", "12.35"},
+		{"ceil(price)", "13"},
+		{"qty / zero", "NaN"},
+		{"max(1, qty / zero)", "NaN"},
+		{".5 + 0.25", "0.75"},
+	}
+	for _, tc := range testCases {
+		e, err := Compile(tc.expr)
+		if err != nil {
+			t.Error("unexpected error", tc.expr, err)
+			continue
+		}
+		f, err := e.Eval(vars)
+		if err != nil || f.String() != tc.out {
+			t.Error("should be equal", tc.expr, f, err, tc.out)
+		}
+	}
+	f, _ := MustCompile("qty / zero").Eval(vars)
+	if f.Kind() != fixed.KindDivByZero {
+		t.Error("should be NaNDivByZero", f.Kind())
+	}
+}
+
+func TestSyntaxErrors(t *testing.T) {
+	for _, s := range []string{
+		"",
+		"1 +",
+		"(1 + 2",
+		"1 + 2)",
+		"1 $ 2",
+		"max()",
+		"abs(1, 2)",
+		"round(1, 2, 3)",
+		"sqrt(4)",
+		"1.2.3",
+		".",
+		"0.00000001",
+		"min(1 2)",
+		"2 3",
+	} {
+		if _, err := Compile(s); !errors.Is(err, ErrSyntax) {
+			t.Error("should be a syntax error", s, err)
+		}
+	}
+	_, err := Compile("1 + * 2")
+	if err == nil || err.Error() != `expr: syntax error: unexpected "*" at offset 4` {
+		t.Error("wrong error", err)
+	}
+	for s, want := range map[string]string{
+		"1 $ 2":  `expr: syntax error: unexpected "$" at offset 2`,
+		"$":      `expr: syntax error: unexpected "$" at offset 0`,
+		"(1 $ 2": `expr: syntax error: expected ")", found "$" at offset 3`,
+		"(1":     `expr: syntax error: expected ")", found end of expression at offset 2`,
+	} {
+		if _, err := Compile(s); err == nil || err.Error() != want {
+			t.Error("wrong error", s, err, want)
+		}
+	}
+}
+
+func TestVars(t *testing.T) {
+	e := MustCompile("b * a + max(a, c) - round(b)")
+	vars := e.Vars()
+	if len(vars) != 3 || vars[0] != "a" || vars[1] != "b" || vars[2] != "c" {
+		t.Error("wrong vars", vars)
+	}
+	if e.String() != "b * a + max(a, c) - round(b)" {
+		t.Error("wrong source", e)
+	}
+	_, err := e.Eval(Vars{"a": fixed.NewS("1"), "b": fixed.NewS("2")})
+	if !errors.Is(err, ErrUnknownVariable) || err.Error() != `expr: unknown variable "c"` {
+		t.Error("wrong error", err)
+	}
+}
+
+func TestEvalAllocs(t *testing.T) {
+	e := MustCompile("notional * 0.0005 + max(1.50, qty * 0.002) - round(-qty / 3, 2) * (qty > 10)")
+	vars := Vars{"notional": fixed.NewS("250000"), "qty": fixed.NewS("1000")}
+	allocs := testing.AllocsPerRun(100, func() {
+		_, _ = e.Eval(vars)
+	})
+	if allocs != 0 {
+		t.Error("should not allocate", allocs)
+	}
+}
@@ -0,0 +1,273 @@
+package expr
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/robaho/fixed"
+)
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokNumber
+	tokIdent
+	tokAdd
+	tokSub
+	tokMul
+	tokDiv
+	tokLParen
+	tokRParen
+	tokComma
+	tokLT
+	tokLE
+	tokGT
+	tokGE
+	tokEQ
+	tokNE
+	// tokInvalid is an unrecognized character, reported as unexpected by the parser
+	tokInvalid
+)
+
+var roundingModes = map[string]fixed.RoundingMode{
+	"round": fixed.RoundHalfUp, "floor": fixed.RoundFloor, "ceil": fixed.RoundCeiling,
+}
+
+var operators = map[string]tokenKind{
+	"+": tokAdd, "-": tokSub, "*": tokMul, "/": tokDiv, "(": tokLParen, ")": tokRParen, ",": tokComma,
+	"<": tokLT, "<=": tokLE, ">": tokGT, ">=": tokGE, "==": tokEQ, "!=": tokNE,
+}
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+type lexer struct {
+	src string
+	pos int
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isLetter(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c == '_'
+}
+
+// scan returns the next token. An unrecognized character is returned as a token of kind tokInvalid with its text
+func (l *lexer) scan() token {
+	for l.pos < len(l.src) && (l.src[l.pos] == ' ' || l.src[l.pos] == '\t' || l.src[l.pos] == '\n') {
+		l.pos++
+	}
+	start := l.pos
+	if l.pos == len(l.src) {
+		return token{kind: tokEOF, pos: start}
+	}
+	c := l.src[l.pos]
+	switch {
+	case isDigit(c) || c == '.':
+		for l.pos < len(l.src) && (isDigit(l.src[l.pos]) || l.src[l.pos] == '.') {
+			l.pos++
+		}
+		return token{kind: tokNumber, text: l.src[start:l.pos], pos: start}
+	case isLetter(c):
+		for l.pos < len(l.src) && (isLetter(l.src[l.pos]) || isDigit(l.src[l.pos])) {
+			l.pos++
+		}
+		return token{kind: tokIdent, text: l.src[start:l.pos], pos: start}
+	}
+	if l.pos+1 < len(l.src) {
+		if kind, ok := operators[l.src[l.pos:l.pos+2]]; ok {
+			l.pos += 2
+			return token{kind: kind, text: l.src[start:l.pos], pos: start}
+		}
+	}
+	l.pos++
+	kind, ok := operators[l.src[start:l.pos]]
+	if !ok {
+		kind = tokInvalid
+	}
+	return token{kind: kind, text: l.src[start:l.pos], pos: start}
+}
+
+// parser is a recursive descent parser, with one function per precedence level
+type parser struct {
+	lexer
+	tok  token
+	vars map[string]bool
+}
+
+func (p *parser) next() {
+	p.tok = p.scan()
+}
+
+func (p *parser) errorf(format string, args ...any) error {
+	return fmt.Errorf("%w: %s at offset %d", ErrSyntax, fmt.Sprintf(format, args...), p.tok.pos)
+}
+
+func (p *parser) expect(kind tokenKind, text string) error {
+	if p.tok.kind != kind {
+		if p.tok.kind == tokEOF {
+			return p.errorf("expected %q, found end of expression", text)
+		}
+		return p.errorf("expected %q, found %q", text, p.tok.text)
+	}
+	p.next()
+	return nil
+}
+
+// parseExpr parses a comparison, which has the lowest precedence
+func (p *parser) parseExpr() (node, error) {
+	x, err := p.parseSum()
+	if err != nil {
+		return nil, err
+	}
+	for p.tok.kind >= tokLT && p.tok.kind <= tokNE {
+		op := p.tok.kind
+		p.next()
+		y, err := p.parseSum()
+		if err != nil {
+			return nil, err
+		}
+		x = binary{op: op, x: x, y: y}
+	}
+	return x, nil
+}
+
+func (p *parser) parseSum() (node, error) {
+	x, err := p.parseProduct()
+	if err != nil {
+		return nil, err
+	}
+	for p.tok.kind == tokAdd || p.tok.kind == tokSub {
+		op := p.tok.kind
+		p.next()
+		y, err := p.parseProduct()
+		if err != nil {
+			return nil, err
+		}
+		x = binary{op: op, x: x, y: y}
+	}
+	return x, nil
+}
+
+func (p *parser) parseProduct() (node, error) {
+	x, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.tok.kind == tokMul || p.tok.kind == tokDiv {
+		op := p.tok.kind
+		p.next()
+		y, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		x = binary{op: op, x: x, y: y}
+	}
+	return x, nil
+}
+
+func (p *parser) parseUnary() (node, error) {
+	switch p.tok.kind {
+	case tokSub:
+		p.next()
+		x, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return negate{x: x}, nil
+	case tokAdd:
+		p.next()
+		return p.parseUnary()
+	}
+	return p.parsePrimary()
+}
+
+func (p *parser) parsePrimary() (node, error) {
+	tok := p.tok
+	switch tok.kind {
+	case tokNumber:
+		// numbers with more than 7 decimal places are rejected rather than truncated
+		point := strings.IndexByte(tok.text, '.')
+		f, err := fixed.NewSErr(tok.text)
+		if err != nil || !f.IsFinite() || tok.text == "." || point >= 0 && len(tok.text)-point-1 > 7 {
+			return nil, p.errorf("invalid number %q", tok.text)
+		}
+		p.next()
+		return constant{value: f}, nil
+	case tokIdent:
+		p.next()
+		if p.tok.kind == tokLParen {
+			return p.parseCall(tok)
+		}
+		p.vars[tok.text] = true
+		return variable{name: tok.text}, nil
+	case tokLParen:
+		p.next()
+		x, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(tokRParen, ")"); err != nil {
+			return nil, err
+		}
+		return x, nil
+	case tokEOF:
+		return nil, p.errorf("unexpected end of expression")
+	}
+	return nil, p.errorf("unexpected %q", tok.text)
+}
+
+// parseCall parses the arguments of a call to the function named by tok, and checks their number
+func (p *parser) parseCall(tok token) (node, error) {
+	p.next()
+	var args []node
+	for p.tok.kind != tokRParen {
+		if len(args) > 0 {
+			if err := p.expect(tokComma, ","); err != nil {
+				return nil, err
+			}
+		}
+		arg, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, arg)
+	}
+	p.next()
+
+	name := tok.text
+	argc := func(min, max int) error {
+		if len(args) < min || len(args) > max {
+			return fmt.Errorf("%w: wrong number of arguments to %s at offset %d", ErrSyntax, name, tok.pos)
+		}
+		return nil
+	}
+	var optional node
+	if len(args) == 2 {
+		optional = args[1]
+	}
+	switch name {
+	case "min", "max":
+		if err := argc(1, len(args)); err != nil {
+			return nil, err
+		}
+		return extreme{max: name == "max", args: args}, nil
+	case "abs":
+		if err := argc(1, 1); err != nil {
+			return nil, err
+		}
+		return absolute{x: args[0]}, nil
+	case "round", "floor", "ceil":
+		if err := argc(1, 2); err != nil {
+			return nil, err
+		}
+		return rounding{mode: roundingModes[name], x: args[0], places: optional}, nil
+	}
+	return nil, fmt.Errorf("%w: unknown function %s at offset %d", ErrSyntax, name, tok.pos)
+}
@@ -0,0 +1,149 @@
+package finance
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"math/big"
+	"strconv"
+
+	"github.com/robaho/fixed"
+)
+
+// Method is the repayment structure of a loan
+type Method int
+
+const (
+	// FixedPayment repays the loan with level payments of principal and interest
+	FixedPayment Method = iota
+	// FixedPrincipal repays an equal amount of principal each period, plus the interest due
+	FixedPrincipal
+	// InterestOnly pays only interest, and repays all of the principal with the final payment
+	InterestOnly
+	// Balloon makes level payments which leave Loan.Balloon outstanding, repaid with the final payment
+	Balloon
+)
+
+var ErrInvalidLoan = errors.New("finance: invalid loan")
+
+// Loan describes the terms of a loan to be amortized
+type Loan struct {
+	Principal fixed.Fixed
+	// Rate is the interest rate per period
+	Rate    fixed.Fixed
+	Periods int
+	Method  Method
+	// Balloon is the balance remaining after the final level payment for the Balloon method, repaid together with it
+	Balloon fixed.Fixed
+	// Decimals is the number of decimal places payments are rounded to, typically the minor units of the currency
+	Decimals int
+	// Mode is the rounding applied to the payment and to each period's interest
+	Mode fixed.RoundingMode
+}
+
+// Row is a single period of an amortization schedule. Balance is the principal outstanding after the payment
+type Row struct {
+	Period    int         `json:"period"`
+	Payment   fixed.Fixed `json:"payment"`
+	Interest  fixed.Fixed `json:"interest"`
+	Principal fixed.Fixed `json:"principal"`
+	Balance   fixed.Fixed `json:"balance"`
+}
+
+// Schedule is an amortization schedule. The principal of the rows always sums exactly to the loan principal, any
+// rounding difference being absorbed by the final payment
+type Schedule struct {
+	Rows     []Row `json:"rows"`
+	Decimals int   `json:"-"`
+}
+
+// Amortize generates the repayment schedule for the loan
+func Amortize(loan Loan) (Schedule, error) {
+	if loan.Periods <= 0 || loan.Decimals < 0 || loan.Decimals > fixedplaces {
+		return Schedule{}, ErrInvalidLoan
+	}
+	if anyNonFinite(loan.Principal, loan.Rate) || loan.Principal.Sign() <= 0 || loan.Rate.Sign() < 0 {
+		return Schedule{}, ErrInvalidLoan
+	}
+	balloon := fixed.ZERO
+	if loan.Method == Balloon {
+		if !loan.Balloon.IsFinite() || loan.Balloon.Sign() < 0 || loan.Balloon.GreaterThan(loan.Principal) {
+			return Schedule{}, ErrInvalidLoan
+		}
+		balloon = loan.Balloon
+	}
+
+	var payment, principal fixed.Fixed
+	switch loan.Method {
+	case FixedPayment, Balloon:
+		p := pmt(fromFixed(loan.Rate), loan.Periods, fromFixed(loan.Principal), new(big.Int).Neg(fromFixed(balloon)), End)
+		payment = toFixedN(p.Neg(p), loan.Decimals, loan.Mode)
+	case FixedPrincipal:
+		principal = loan.Principal.DivRound(fixed.NewI(int64(loan.Periods), 0), loan.Decimals, loan.Mode)
+	case InterestOnly:
+		principal = fixed.ZERO
+	default:
+		return Schedule{}, ErrInvalidLoan
+	}
+
+	level := loan.Method == FixedPayment || loan.Method == Balloon
+	rows := make([]Row, loan.Periods)
+	balance := loan.Principal
+	for i := range rows {
+		interest := balance.MulRound(loan.Rate, loan.Decimals, loan.Mode)
+		if level {
+			principal = payment.Sub(interest)
+		}
+		if i == len(rows)-1 || principal.GreaterThan(balance) {
+			principal = balance
+		}
+		balance = balance.Sub(principal)
+		rows[i] = Row{
+			Period:    i + 1,
+			Payment:   principal.Add(interest),
+			Interest:  interest,
+			Principal: principal,
+			Balance:   balance,
+		}
+	}
+	return Schedule{Rows: rows, Decimals: loan.Decimals}, nil
+}
+
+// Totals returns the sum of the payments, interest and principal of the schedule
+func (s Schedule) Totals() (payment, interest, principal fixed.Fixed) {
+	payment, interest, principal = fixed.ZERO, fixed.ZERO, fixed.ZERO
+	for _, row := range s.Rows {
+		payment = payment.Add(row.Payment)
+		interest = interest.Add(row.Interest)
+		principal = principal.Add(row.Principal)
+	}
+	return
+}
+
+// WriteCSV writes the schedule with a header row, formatting amounts with the schedule's decimal places
+func (s Schedule) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"period", "payment", "interest", "principal", "balance"}); err != nil {
+		return err
+	}
+	for _, row := range s.Rows {
+		record := []string{
+			strconv.Itoa(row.Period),
+			row.Payment.StringN(s.Decimals),
+			row.Interest.StringN(s.Decimals),
+			row.Principal.StringN(s.Decimals),
+			row.Balance.StringN(s.Decimals),
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteJSON writes the rows of the schedule as a JSON array
+func (s Schedule) WriteJSON(w io.Writer) error {
+	return json.NewEncoder(w).Encode(s.Rows)
+}
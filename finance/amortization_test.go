@@ -0,0 +1,150 @@
+package finance
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/robaho/fixed"
+)
+
+func checkSchedule(t *testing.T, s Schedule, loan Loan) {
+	t.Helper()
+	if len(s.Rows) != loan.Periods {
+		t.Fatal("wrong number of rows", len(s.Rows), loan.Periods)
+	}
+	payment, interest, principal := s.Totals()
+	if !principal.Equal(loan.Principal) {
+		t.Error("principal should sum to loan amount", principal, loan.Principal)
+	}
+	if !payment.Equal(principal.Add(interest)) {
+		t.Error("payments should be principal plus interest", payment, principal, interest)
+	}
+	last := s.Rows[len(s.Rows)-1]
+	if !last.Balance.IsZero() {
+		t.Error("final balance should be zero", last.Balance)
+	}
+	for _, row := range s.Rows {
+		if !row.Payment.Equal(row.Principal.Add(row.Interest)) {
+			t.Error("payment should be principal plus interest", row)
+		}
+		if !row.Interest.Equal(row.Interest.RoundTo(loan.Decimals, fixed.RoundDown)) {
+			t.Error("interest not rounded", row)
+		}
+	}
+}
+
+func TestAmortizeFixedPayment(t *testing.T) {
+	loan := Loan{Principal: fixed.NewS("200000"), Rate: fixed.NewS("0.005"), Periods: 360, Decimals: 2}
+	s, err := Amortize(loan)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkSchedule(t, s, loan)
+	first := s.Rows[0]
+	if first.Payment.String() != "1199.1" || first.Interest.String() != "1000" || first.Principal.String() != "199.1" {
+		t.Error("wrong first row", first)
+	}
+	if first.Balance.String() != "199800.9" {
+		t.Error("should be equal", first.Balance, "199800.9")
+	}
+	for _, row := range s.Rows[:len(s.Rows)-1] {
+		if !row.Payment.Equal(first.Payment) {
+			t.Error("payments should be level", row)
+		}
+	}
+	last := s.Rows[len(s.Rows)-1]
+	if last.Payment.String() != "1200.14" {
+		t.Error("wrong final payment", last)
+	}
+}
+
+func TestAmortizeFixedPrincipal(t *testing.T) {
+	loan := Loan{Principal: fixed.NewS("1000"), Rate: fixed.NewS("0.01"), Periods: 3, Method: FixedPrincipal, Decimals: 2}
+	s, err := Amortize(loan)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkSchedule(t, s, loan)
+	expected := []string{"343.33", "340.00", "336.67"}
+	for i, row := range s.Rows {
+		if row.Payment.StringN(2) != expected[i] {
+			t.Error("should be equal", row.Payment, expected[i])
+		}
+	}
+	if s.Rows[2].Principal.String() != "333.34" {
+		t.Error("final principal should absorb the rounding", s.Rows[2].Principal)
+	}
+}
+
+func TestAmortizeInterestOnly(t *testing.T) {
+	loan := Loan{Principal: fixed.NewS("1000"), Rate: fixed.NewS("0.01"), Periods: 4, Method: InterestOnly, Decimals: 2}
+	s, err := Amortize(loan)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkSchedule(t, s, loan)
+	if s.Rows[0].Payment.String() != "10" || s.Rows[3].Payment.String() != "1010" {
+		t.Error("wrong payments", s.Rows)
+	}
+}
+
+func TestAmortizeBalloon(t *testing.T) {
+	loan := Loan{Principal: fixed.NewS("100000"), Rate: fixed.NewS("0.005"), Periods: 60, Method: Balloon, Balloon: fixed.NewS("50000"), Decimals: 2}
+	s, err := Amortize(loan)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkSchedule(t, s, loan)
+	payment := s.Rows[0].Payment
+	if payment.String() != "1216.64" {
+		t.Error("should be equal", payment, "1216.64")
+	}
+	last := s.Rows[len(s.Rows)-1]
+	if last.Payment.Sub(payment).Sub(fixed.NewS("50000")).Abs().GreaterThan(fixed.NewS("1")) {
+		t.Error("final payment should include the balloon", last)
+	}
+}
+
+func TestAmortizeInvalid(t *testing.T) {
+	loans := []Loan{
+		{Principal: fixed.NewS("1000"), Rate: fixed.NewS("0.01"), Periods: 0},
+		{Principal: fixed.NaN, Rate: fixed.NewS("0.01"), Periods: 12},
+		{Principal: fixed.NewS("1000"), Rate: fixed.NewS("-0.01"), Periods: 12},
+		{Principal: fixed.NewS("1000"), Rate: fixed.NewS("0.01"), Periods: 12, Method: Balloon, Balloon: fixed.NewS("2000")},
+	}
+	for _, loan := range loans {
+		if _, err := Amortize(loan); err != ErrInvalidLoan {
+			t.Error("expected ErrInvalidLoan", loan, err)
+		}
+	}
+}
+
+func TestScheduleExport(t *testing.T) {
+	loan := Loan{Principal: fixed.NewS("1000"), Rate: fixed.NewS("0.01"), Periods: 2, Method: FixedPrincipal, Decimals: 2}
+	s, err := Amortize(loan)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := s.WriteCSV(&buf); err != nil {
+		t.Fatal(err)
+	}
+	expected := "period,payment,interest,principal,balance\n1,510.00,10.00,500.00,500.00\n2,505.00,5.00,500.00,0.00\n"
+	if buf.String() != expected {
+		t.Error("should be equal", buf.String(), expected)
+	}
+
+	buf.Reset()
+	if err := s.WriteJSON(&buf); err != nil {
+		t.Fatal(err)
+	}
+	var rows []Row
+	if err := json.NewDecoder(strings.NewReader(buf.String())).Decode(&rows); err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 2 || !rows[0].Payment.Equal(fixed.NewS("510")) || !rows[1].Balance.IsZero() {
+		t.Error("wrong rows", rows)
+	}
+}
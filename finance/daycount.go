@@ -0,0 +1,169 @@
+package finance
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/robaho/fixed"
+)
+
+// Convention is a day count convention, which determines the fraction of a year between two dates
+type Convention int
+
+const (
+	// ACT360 is actual days / 360
+	ACT360 Convention = iota
+	// ACT365F is actual days / 365, regardless of leap years
+	ACT365F
+	// Thirty360US is the US (NASD/SIA) 30/360 bond basis, including the end of February adjustments
+	Thirty360US
+	// Thirty360EU is the European 30E/360 Eurobond basis
+	Thirty360EU
+	// ACTACTISDA divides the days falling in leap years by 366 and the remaining days by 365
+	ACTACTISDA
+	// ACTACTICMA divides the days by the length of the coupon period times the coupon frequency. Used with
+	// YearFraction the coupon periods are taken to be annual, starting at the start date; use YearFractionICMA to
+	// specify the coupon period
+	ACTACTICMA
+)
+
+// YearFraction returns the fraction of a year from start to end using the day count convention, rounded
+// half-even to 7 places. If end is before start the result is negative. The time of day is ignored. NaN is returned
+// if the convention is unknown
+func YearFraction(start, end time.Time, c Convention) fixed.Fixed {
+	return ratToFixed(yearFraction(start, end, c), fixed.RoundHalfEven)
+}
+
+// Accrued returns the interest accrued on notional at the annual rate from start to end using the day count
+// convention. The year fraction is not rounded, so the result is exact before a single half-even rounding to 7 places
+func Accrued(notional, rate fixed.Fixed, start, end time.Time, c Convention) fixed.Fixed {
+	if anyNonFinite(notional, rate) {
+		return fixed.NaN
+	}
+	return accrued(notional, rate, yearFraction(start, end, c))
+}
+
+// YearFractionICMA returns the ACT/ACT ICMA fraction of a year from start to end, which fall within the coupon period
+// from periodStart to periodEnd, for a bond paying frequency coupons a year
+func YearFractionICMA(start, end, periodStart, periodEnd time.Time, frequency int) fixed.Fixed {
+	return ratToFixed(icma(start, end, periodStart, periodEnd, frequency), fixed.RoundHalfEven)
+}
+
+// AccruedICMA returns the ACT/ACT ICMA interest accrued on notional at the annual rate from start to end, which fall
+// within the coupon period from periodStart to periodEnd, for a bond paying frequency coupons a year
+func AccruedICMA(notional, rate fixed.Fixed, start, end, periodStart, periodEnd time.Time, frequency int) fixed.Fixed {
+	if anyNonFinite(notional, rate) {
+		return fixed.NaN
+	}
+	return accrued(notional, rate, icma(start, end, periodStart, periodEnd, frequency))
+}
+
+func accrued(notional, rate fixed.Fixed, yf *big.Rat) fixed.Fixed {
+	if yf == nil {
+		return fixed.NaN
+	}
+	n, _ := notional.ToBigRat()
+	r, _ := rate.ToBigRat()
+	a := new(big.Rat).Mul(n, r)
+	return ratToFixed(a.Mul(a, yf), fixed.RoundHalfEven)
+}
+
+func ratio(days, basis int64) *big.Rat {
+	return big.NewRat(days, basis)
+}
+
+// yearFraction returns the exact fraction of a year, or nil if the convention is unknown
+func yearFraction(start, end time.Time, c Convention) *big.Rat {
+	if dayNumber(end) < dayNumber(start) {
+		yf := yearFraction(end, start, c)
+		if yf == nil {
+			return nil
+		}
+		return yf.Neg(yf)
+	}
+	switch c {
+	case ACT360:
+		return ratio(dayNumber(end)-dayNumber(start), 360)
+	case ACT365F:
+		return ratio(dayNumber(end)-dayNumber(start), 365)
+	case Thirty360US:
+		return ratio(days30360(start, end, true), 360)
+	case Thirty360EU:
+		return ratio(days30360(start, end, false), 360)
+	case ACTACTISDA:
+		return actActISDA(start, end)
+	case ACTACTICMA:
+		yf := new(big.Rat)
+		for {
+			next := start.AddDate(1, 0, 0)
+			if dayNumber(next) >= dayNumber(end) {
+				return yf.Add(yf, icma(start, end, start, next, 1))
+			}
+			yf.Add(yf, big.NewRat(1, 1))
+			start = next
+		}
+	}
+	return nil
+}
+
+func isLastDayOfFebruary(t time.Time) bool {
+	return t.Month() == time.February && t.AddDate(0, 0, 1).Month() == time.March
+}
+
+func days30360(start, end time.Time, us bool) int64 {
+	y1, m1, d1 := start.Date()
+	y2, m2, d2 := end.Date()
+	if us {
+		if isLastDayOfFebruary(start) {
+			if isLastDayOfFebruary(end) {
+				d2 = 30
+			}
+			d1 = 30
+		}
+		if d2 == 31 && d1 >= 30 {
+			d2 = 30
+		}
+		if d1 == 31 {
+			d1 = 30
+		}
+	} else {
+		if d1 == 31 {
+			d1 = 30
+		}
+		if d2 == 31 {
+			d2 = 30
+		}
+	}
+	return int64(360*(y2-y1) + 30*(int(m2)-int(m1)) + (d2 - d1))
+}
+
+func daysInYear(year int) int64 {
+	if year%4 == 0 && (year%100 != 0 || year%400 == 0) {
+		return 366
+	}
+	return 365
+}
+
+func actActISDA(start, end time.Time) *big.Rat {
+	yf := new(big.Rat)
+	d0, d1 := dayNumber(start), dayNumber(end)
+	for year := start.Year(); year <= end.Year(); year++ {
+		from := dayNumber(time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC))
+		to := dayNumber(time.Date(year+1, time.January, 1, 0, 0, 0, 0, time.UTC))
+		from, to = max(from, d0), min(to, d1)
+		if to > from {
+			yf.Add(yf, ratio(to-from, daysInYear(year)))
+		}
+	}
+	return yf
+}
+
+// icma returns the exact ACT/ACT ICMA fraction of a year, or nil if the coupon period or frequency is invalid
+func icma(start, end, periodStart, periodEnd time.Time, frequency int) *big.Rat {
+	days := dayNumber(end) - dayNumber(start)
+	period := dayNumber(periodEnd) - dayNumber(periodStart)
+	if period <= 0 || frequency <= 0 {
+		return nil
+	}
+	return ratio(days, period*int64(frequency))
+}
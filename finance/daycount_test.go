@@ -0,0 +1,73 @@
+package finance
+
+import (
+	"testing"
+	"time"
+
+	"github.com/robaho/fixed"
+)
+
+func TestYearFraction(t *testing.T) {
+	testCases := []struct {
+		start, end time.Time
+		c          Convention
+		yf         string
+	}{
+		{date(2024, 1, 15), date(2024, 7, 15), ACT360, "0.5055556"},
+		{date(2024, 1, 15), date(2024, 7, 15), ACT365F, "0.4986301"},
+		{date(2024, 1, 31), date(2024, 3, 31), Thirty360US, "0.1666667"},
+		{date(2023, 2, 28), date(2023, 8, 31), Thirty360US, "0.5"},
+		{date(2023, 2, 28), date(2023, 8, 31), Thirty360EU, "0.5055556"},
+		{date(2024, 1, 31), date(2024, 3, 31), Thirty360EU, "0.1666667"},
+		{date(2023, 7, 1), date(2024, 7, 1), ACTACTISDA, "1.0013773"},
+		{date(2024, 1, 1), date(2025, 1, 1), ACTACTISDA, "1"},
+		{date(2024, 1, 15), date(2024, 7, 15), ACTACTICMA, "0.4972678"},
+		{date(2023, 1, 15), date(2024, 7, 15), ACTACTICMA, "1.4972678"},
+		{date(2024, 7, 15), date(2024, 1, 15), ACT360, "-0.5055556"},
+		{date(2024, 1, 15), date(2024, 1, 15), ACT360, "0"},
+	}
+	for _, tc := range testCases {
+		yf := YearFraction(tc.start, tc.end, tc.c)
+		if yf.String() != tc.yf {
+			t.Error("should be equal", tc.start, tc.end, tc.c, yf, tc.yf)
+		}
+	}
+	if !YearFraction(date(2024, 1, 1), date(2024, 2, 1), Convention(99)).IsNaN() {
+		t.Error("unknown convention should be NaN")
+	}
+}
+
+func TestYearFractionICMA(t *testing.T) {
+	yf := YearFractionICMA(date(2024, 1, 15), date(2024, 4, 15), date(2024, 1, 15), date(2024, 7, 15), 2)
+	if yf.String() != "0.25" {
+		t.Error("should be equal", yf, "0.25")
+	}
+	yf = YearFractionICMA(date(2024, 1, 15), date(2024, 4, 15), date(2024, 1, 15), date(2024, 7, 15), 0)
+	if !yf.IsNaN() {
+		t.Error("should be NaN", yf)
+	}
+}
+
+func TestAccrued(t *testing.T) {
+	notional, rate := fixed.NewS("1000000"), fixed.NewS("0.05")
+	a := Accrued(notional, rate, date(2024, 1, 15), date(2024, 7, 15), ACT360)
+	if a.String() != "25277.7777778" {
+		t.Error("should be equal", a, "25277.7777778")
+	}
+	// exact, unlike multiplying by the rounded year fraction
+	rounded := notional.Mul(rate).Mul(YearFraction(date(2024, 1, 15), date(2024, 7, 15), ACT360))
+	if rounded.Equal(a) {
+		t.Error("should differ", rounded, a)
+	}
+	a = Accrued(notional, rate, date(2023, 7, 1), date(2024, 7, 1), ACTACTISDA)
+	if a.String() != "50068.8674302" {
+		t.Error("should be equal", a, "50068.8674302")
+	}
+	a = AccruedICMA(notional, rate, date(2024, 1, 15), date(2024, 4, 15), date(2024, 1, 15), date(2024, 7, 15), 2)
+	if a.String() != "12500" {
+		t.Error("should be equal", a, "12500")
+	}
+	if !Accrued(fixed.NaN, rate, date(2024, 1, 15), date(2024, 7, 15), ACT360).IsNaN() {
+		t.Error("should be NaN")
+	}
+}
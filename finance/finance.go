@@ -0,0 +1,297 @@
+// Package finance provides interest, discounting and time value of money functions using fixed.Fixed.
+//
+// The functions follow the spreadsheet conventions for the sign of cash flows: money paid out is negative and money
+// received is positive. Rates are per period, e.g. 0.005 for 6% a year paid monthly.
+//
+// All intermediate values are computed with 40 decimal places and only the result is rounded, once, to the 7 places
+// of a Fixed using the supplied rounding mode, so results are exact to the last place and reproducible on every
+// platform. No floating point is used. If an argument is NaN or infinite the result is NaN, and a result that is out
+// of range is fixed.NaNOverflow.
+package finance
+
+import (
+	"errors"
+	"math/big"
+	"time"
+
+	"github.com/robaho/fixed"
+)
+
+// Timing specifies whether payments are made at the end or the beginning of each period
+type Timing int
+
+const (
+	End Timing = iota
+	Begin
+)
+
+var ErrNoConvergence = errors.New("finance: solution did not converge")
+var ErrNoSolution = errors.New("finance: cash flows must contain both positive and negative values")
+var ErrLength = errors.New("finance: values and dates must have the same length")
+
+const maxIterations = 100
+
+// tolerance for the iterative solvers, well below the precision of a Fixed
+var tolerance = pow10(wideplaces - 20)
+
+func anyNonFinite(values ...fixed.Fixed) bool {
+	for _, v := range values {
+		if !v.IsFinite() {
+			return true
+		}
+	}
+	return false
+}
+
+// annuityFactor returns (1+r*when)*((1+r)^n-1)/r and (1+r)^n for a non-zero r, and false if 1+r is zero and n is
+// negative
+func annuityFactor(r *big.Int, nper int, when Timing) (factor, growth *big.Int, ok bool) {
+	v := add(wideOne, r)
+	if v.Sign() == 0 && nper < 0 {
+		return nil, nil, false
+	}
+	growth = powInt(v, nper)
+	factor = div(sub(growth, wideOne), r)
+	if when == Begin {
+		factor = mul(factor, v)
+	}
+	return factor, growth, true
+}
+
+// FV returns the future value of an investment with periodic payments pmt and present value pv. NaNDivByZero is
+// returned if rate is -1 and nper is negative
+func FV(rate fixed.Fixed, nper int, pmt, pv fixed.Fixed, when Timing, mode fixed.RoundingMode) fixed.Fixed {
+	if anyNonFinite(rate, pmt, pv) {
+		return fixed.NaN
+	}
+	r, wpmt, wpv := fromFixed(rate), fromFixed(pmt), fromFixed(pv)
+	if r.Sign() == 0 {
+		return toFixed(new(big.Int).Neg(add(wpv, new(big.Int).Mul(wpmt, big.NewInt(int64(nper))))), mode)
+	}
+	factor, growth, ok := annuityFactor(r, nper, when)
+	if !ok {
+		return fixed.NaNDivByZero
+	}
+	fv := add(mul(wpv, growth), mul(wpmt, factor))
+	return toFixed(fv.Neg(fv), mode)
+}
+
+// PV returns the present value of an investment with periodic payments pmt and future value fv. NaNDivByZero is
+// returned if rate is -1
+func PV(rate fixed.Fixed, nper int, pmt, fv fixed.Fixed, when Timing, mode fixed.RoundingMode) fixed.Fixed {
+	if anyNonFinite(rate, pmt, fv) {
+		return fixed.NaN
+	}
+	r, wpmt, wfv := fromFixed(rate), fromFixed(pmt), fromFixed(fv)
+	if r.Sign() == 0 {
+		return toFixed(new(big.Int).Neg(add(wfv, new(big.Int).Mul(wpmt, big.NewInt(int64(nper))))), mode)
+	}
+	factor, growth, ok := annuityFactor(r, nper, when)
+	if !ok || growth.Sign() == 0 {
+		return fixed.NaNDivByZero
+	}
+	pv := div(add(wfv, mul(wpmt, factor)), growth)
+	return toFixed(pv.Neg(pv), mode)
+}
+
+// PMT returns the periodic payment for a loan or annuity with present value pv and future value fv. NaN is
+// returned if nper is not positive, and NaNDivByZero if no payment exists because the annuity factor is zero, e.g. a
+// rate of -2 for an even number of periods
+func PMT(rate fixed.Fixed, nper int, pv, fv fixed.Fixed, when Timing, mode fixed.RoundingMode) fixed.Fixed {
+	if anyNonFinite(rate, pv, fv) || nper <= 0 {
+		return fixed.NaN
+	}
+	p := pmt(fromFixed(rate), nper, fromFixed(pv), fromFixed(fv), when)
+	if p == nil {
+		return fixed.NaNDivByZero
+	}
+	return toFixed(p, mode)
+}
+
+// pmt returns the periodic payment for a positive nper, or nil if the annuity factor is zero
+func pmt(r *big.Int, nper int, pv, fv *big.Int, when Timing) *big.Int {
+	if r.Sign() == 0 {
+		p := divRound(add(pv, fv), big.NewInt(int64(nper)), fixed.RoundHalfEven)
+		return p.Neg(p)
+	}
+	factor, growth, _ := annuityFactor(r, nper, when)
+	if factor.Sign() == 0 {
+		return nil
+	}
+	p := div(add(mul(pv, growth), fv), factor)
+	return p.Neg(p)
+}
+
+// NPV returns the net present value of the cash flows, which occur at the end of periods 1, 2, ... NaNDivByZero is
+// returned if rate is -1
+func NPV(rate fixed.Fixed, values []fixed.Fixed, mode fixed.RoundingMode) fixed.Fixed {
+	if anyNonFinite(rate) || anyNonFinite(values...) {
+		return fixed.NaN
+	}
+	r := fromFixed(rate)
+	if add(wideOne, r).Sign() == 0 {
+		return fixed.NaNDivByZero
+	}
+	npv, _ := npv(r, wideValues(values), 1)
+	return toFixed(npv, mode)
+}
+
+func wideValues(values []fixed.Fixed) []*big.Int {
+	w := make([]*big.Int, len(values))
+	for i, v := range values {
+		w[i] = fromFixed(v)
+	}
+	return w
+}
+
+// npv returns the net present value of values discounted from period first, and its derivative with respect to r,
+// which must not be -1
+func npv(r *big.Int, values []*big.Int, first int) (npv, deriv *big.Int) {
+	npv, deriv = new(big.Int), new(big.Int)
+	v := add(wideOne, r)
+	discount := powInt(v, -first)
+	vinv := div(wideOne, v)
+	for i, value := range values {
+		pv := mul(value, discount)
+		npv.Add(npv, pv)
+		n := int64(first + i)
+		deriv.Sub(deriv, mul(new(big.Int).Mul(pv, big.NewInt(n)), vinv))
+		discount = mul(discount, vinv)
+	}
+	return
+}
+
+func hasSignChange(values []fixed.Fixed) bool {
+	var pos, neg bool
+	for _, v := range values {
+		pos = pos || v.Sign() > 0
+		neg = neg || v.Sign() < 0
+	}
+	return pos && neg
+}
+
+// newton solves f(r) = 0 starting at guess, where fn returns f(r) and f'(r). Every r, including the guess, must be
+// greater than -1
+func newton(guess fixed.Fixed, fn func(r *big.Int) (*big.Int, *big.Int), mode fixed.RoundingMode) (fixed.Fixed, error) {
+	minRate := new(big.Int).Neg(wideOne)
+	r := fromFixed(guess)
+	if r.Cmp(minRate) <= 0 {
+		return fixed.NaN, ErrNoConvergence
+	}
+	for i := 0; i < maxIterations; i++ {
+		f, df := fn(r)
+		if df.Sign() == 0 {
+			return fixed.NaN, ErrNoConvergence
+		}
+		delta := div(f, df)
+		r = sub(r, delta)
+		if r.Cmp(minRate) <= 0 {
+			return fixed.NaN, ErrNoConvergence
+		}
+		if delta.CmpAbs(tolerance) < 0 {
+			return toFixed(r, mode), nil
+		}
+	}
+	return fixed.NaN, ErrNoConvergence
+}
+
+// IRR returns the internal rate of return of the cash flows, which occur at the start of periods 0, 1, 2, ..., i.e.
+// the rate for which their net present value is zero. The solution is found by Newton's method starting at guess,
+// with a fixed maximum number of iterations, so the result is deterministic. ErrNoConvergence is returned if guess is
+// not greater than -1.
+func IRR(values []fixed.Fixed, guess fixed.Fixed, mode fixed.RoundingMode) (fixed.Fixed, error) {
+	if anyNonFinite(guess) || anyNonFinite(values...) {
+		return fixed.NaN, ErrNoConvergence
+	}
+	if !hasSignChange(values) {
+		return fixed.NaN, ErrNoSolution
+	}
+	w := wideValues(values)
+	return newton(guess, func(r *big.Int) (*big.Int, *big.Int) {
+		return npv(r, w, 0)
+	}, mode)
+}
+
+// dayNumber returns the number of days from the epoch to the calendar date of t, ignoring the time of day
+func dayNumber(t time.Time) int64 {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC).Unix() / 86400
+}
+
+// yearFractions returns the time of each date from the first date in years of 365 days
+func yearFractions(dates []time.Time) []*big.Int {
+	t := make([]*big.Int, len(dates))
+	d0 := dayNumber(dates[0])
+	for i, d := range dates {
+		t[i] = div(fromInt(dayNumber(d)-d0), fromInt(365))
+	}
+	return t
+}
+
+// xnpv returns the net present value of the dated cash flows, and its derivative with respect to r
+func xnpv(r *big.Int, values, times []*big.Int) (npv, deriv *big.Int) {
+	npv, deriv = new(big.Int), new(big.Int)
+	v := add(wideOne, r)
+	lnv := ln(v)
+	for i, value := range values {
+		// value / v^t
+		pv := div(value, exp(mul(times[i], lnv)))
+		npv.Add(npv, pv)
+		deriv.Sub(deriv, div(mul(pv, times[i]), v))
+	}
+	return
+}
+
+// XNPV returns the net present value of cash flows occurring on the specified dates, discounted to the first date
+// using years of 365 days
+func XNPV(rate fixed.Fixed, values []fixed.Fixed, dates []time.Time, mode fixed.RoundingMode) (fixed.Fixed, error) {
+	if len(values) != len(dates) || len(values) == 0 {
+		return fixed.NaN, ErrLength
+	}
+	if anyNonFinite(rate) || anyNonFinite(values...) || rate.LessThanOrEqual(fixed.NewI(-1, 0)) {
+		return fixed.NaN, nil
+	}
+	npv, _ := xnpv(fromFixed(rate), wideValues(values), yearFractions(dates))
+	return toFixed(npv, mode), nil
+}
+
+// XIRR returns the internal rate of return of cash flows occurring on the specified dates, i.e. the rate for which
+// XNPV is zero. The solution is found by Newton's method starting at guess, with a fixed maximum number of iterations,
+// so the result is deterministic. ErrNoConvergence is returned if guess is not greater than -1.
+func XIRR(values []fixed.Fixed, dates []time.Time, guess fixed.Fixed, mode fixed.RoundingMode) (fixed.Fixed, error) {
+	if len(values) != len(dates) || len(values) == 0 {
+		return fixed.NaN, ErrLength
+	}
+	if anyNonFinite(guess) || anyNonFinite(values...) {
+		return fixed.NaN, ErrNoConvergence
+	}
+	if !hasSignChange(values) {
+		return fixed.NaN, ErrNoSolution
+	}
+	w, times := wideValues(values), yearFractions(dates)
+	return newton(guess, func(r *big.Int) (*big.Int, *big.Int) {
+		return xnpv(r, w, times)
+	}, mode)
+}
+
+// SimpleInterest returns the interest accrued on principal at rate for the number of periods, principal*rate*periods
+func SimpleInterest(principal, rate, periods fixed.Fixed, mode fixed.RoundingMode) fixed.Fixed {
+	if anyNonFinite(principal, rate, periods) {
+		return fixed.NaN
+	}
+	return toFixed(mul(mul(fromFixed(principal), fromFixed(rate)), fromFixed(periods)), mode)
+}
+
+// CompoundInterest returns the interest accrued on principal at rate compounded for nper periods,
+// principal*((1+rate)^nper-1). NaNDivByZero is returned if rate is -1 and nper is negative
+func CompoundInterest(principal, rate fixed.Fixed, nper int, mode fixed.RoundingMode) fixed.Fixed {
+	if anyNonFinite(principal, rate) {
+		return fixed.NaN
+	}
+	v := add(wideOne, fromFixed(rate))
+	if v.Sign() == 0 && nper < 0 {
+		return fixed.NaNDivByZero
+	}
+	growth := powInt(v, nper)
+	return toFixed(mul(fromFixed(principal), sub(growth, wideOne)), mode)
+}
@@ -0,0 +1,193 @@
+package finance
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/robaho/fixed"
+)
+
+func fs(values ...string) []fixed.Fixed {
+	var result []fixed.Fixed
+	for _, s := range values {
+		result = append(result, fixed.NewS(s))
+	}
+	return result
+}
+
+func date(y int, m time.Month, d int) time.Time {
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestPMT(t *testing.T) {
+	f := PMT(fixed.NewS("0.005"), 360, fixed.NewS("200000"), fixed.ZERO, End, fixed.RoundHalfEven)
+	if f.String() != "-1199.1010503" {
+		t.Error("should be equal", f, "-1199.1010503")
+	}
+	f = PMT(fixed.NewS("0.005"), 360, fixed.NewS("200000"), fixed.ZERO, End, fixed.RoundHalfUp).RoundTo(2, fixed.RoundHalfUp)
+	if f.String() != "-1199.1" {
+		t.Error("should be equal", f, "-1199.1")
+	}
+	f = PMT(fixed.ZERO, 12, fixed.NewS("1200"), fixed.ZERO, End, fixed.RoundHalfEven)
+	if f.String() != "-100" {
+		t.Error("should be equal", f, "-100")
+	}
+	f = PMT(fixed.NaN, 12, fixed.NewS("1200"), fixed.ZERO, End, fixed.RoundHalfEven)
+	if !f.IsNaN() {
+		t.Error("should be NaN", f)
+	}
+}
+
+func TestFV(t *testing.T) {
+	f := FV(fixed.NewS("0.005"), 120, fixed.NewS("-100"), fixed.NewS("-1000"), End, fixed.RoundHalfEven)
+	if f.String() != "18207.3314147" {
+		t.Error("should be equal", f, "18207.3314147")
+	}
+	f = FV(fixed.NewS("0.005"), 120, fixed.NewS("-100"), fixed.NewS("-1000"), Begin, fixed.RoundHalfEven)
+	if f.String() != "18289.2710881" {
+		t.Error("should be equal", f, "18289.2710881")
+	}
+}
+
+func TestPV(t *testing.T) {
+	f := PV(fixed.NewS("0.0066667"), 240, fixed.NewS("500"), fixed.ZERO, End, fixed.RoundHalfEven)
+	if f.String() != "-59776.9679422" {
+		t.Error("should be equal", f, "-59776.9679422")
+	}
+}
+
+func TestNPV(t *testing.T) {
+	f := NPV(fixed.NewS("0.1"), fs("-10000", "3000", "4200", "6800"), fixed.RoundHalfEven)
+	if f.String() != "1188.4434123" {
+		t.Error("should be equal", f, "1188.4434123")
+	}
+}
+
+func TestIRR(t *testing.T) {
+	f, err := IRR(fs("-70000", "12000", "15000", "18000", "21000", "26000"), fixed.NewS("0.1"), fixed.RoundHalfEven)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f.String() != "0.0866309" {
+		t.Error("should be equal", f, "0.0866309")
+	}
+	_, err = IRR(fs("100", "200"), fixed.NewS("0.1"), fixed.RoundHalfEven)
+	if !errors.Is(err, ErrNoSolution) {
+		t.Error("expected ErrNoSolution", err)
+	}
+}
+
+var xvalues = fs("-10000", "2750", "4250", "3250", "2750")
+var xdates = []time.Time{date(2008, 1, 1), date(2008, 3, 1), date(2008, 10, 30), date(2009, 2, 15), date(2009, 4, 1)}
+
+func TestXNPV(t *testing.T) {
+	f, err := XNPV(fixed.NewS("0.09"), xvalues, xdates, fixed.RoundHalfEven)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f.String() != "2086.647602" {
+		t.Error("should be equal", f, "2086.647602")
+	}
+	_, err = XNPV(fixed.NewS("0.09"), xvalues, xdates[1:], fixed.RoundHalfEven)
+	if !errors.Is(err, ErrLength) {
+		t.Error("expected ErrLength", err)
+	}
+}
+
+func TestXIRR(t *testing.T) {
+	f, err := XIRR(xvalues, xdates, fixed.NewS("0.1"), fixed.RoundHalfEven)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f.String() != "0.3733625" {
+		t.Error("should be equal", f, "0.3733625")
+	}
+}
+
+func TestInterest(t *testing.T) {
+	f := SimpleInterest(fixed.NewS("10000"), fixed.NewS("0.05"), fixed.NewS("2.5"), fixed.RoundHalfEven)
+	if f.String() != "1250" {
+		t.Error("should be equal", f, "1250")
+	}
+	f = CompoundInterest(fixed.NewS("10000"), fixed.NewS("0.05"), 10, fixed.RoundHalfEven)
+	if f.String() != "6288.9462678" {
+		t.Error("should be equal", f, "6288.9462678")
+	}
+}
+
+func TestDeterministic(t *testing.T) {
+	first := PMT(fixed.NewS("0.0041667"), 360, fixed.NewS("350000"), fixed.ZERO, End, fixed.RoundHalfEven)
+	for i := 0; i < 10; i++ {
+		f := PMT(fixed.NewS("0.0041667"), 360, fixed.NewS("350000"), fixed.ZERO, End, fixed.RoundHalfEven)
+		if !f.Equal(first) {
+			t.Error("should be equal", f, first)
+		}
+	}
+}
+
+func TestDivisionByZero(t *testing.T) {
+	minusOne := fixed.NewI(-1, 0)
+	if f := NPV(minusOne, fs("100", "100"), fixed.RoundHalfEven); f.Kind() != fixed.KindDivByZero {
+		t.Error("should be NaN(DivByZero)", f.Kind())
+	}
+	if f := PV(minusOne, 3, fixed.NewS("-100"), fixed.ZERO, End, fixed.RoundHalfEven); f.Kind() != fixed.KindDivByZero {
+		t.Error("should be NaN(DivByZero)", f.Kind())
+	}
+	if f := PV(minusOne, -3, fixed.NewS("-100"), fixed.ZERO, Begin, fixed.RoundHalfEven); f.Kind() != fixed.KindDivByZero {
+		t.Error("should be NaN(DivByZero)", f.Kind())
+	}
+	if f := FV(minusOne, -3, fixed.NewS("-100"), fixed.ZERO, End, fixed.RoundHalfEven); f.Kind() != fixed.KindDivByZero {
+		t.Error("should be NaN(DivByZero)", f.Kind())
+	}
+	// (1+r)^n-1 is zero
+	if f := PMT(fixed.NewI(-2, 0), 2, fixed.NewS("1000"), fixed.ZERO, End, fixed.RoundHalfEven); f.Kind() != fixed.KindDivByZero {
+		t.Error("should be NaN(DivByZero)", f.Kind())
+	}
+	// 1+r is zero, so the factor is zero when payments are at the beginning
+	if f := PMT(minusOne, 3, fixed.NewS("1000"), fixed.ZERO, Begin, fixed.RoundHalfEven); f.Kind() != fixed.KindDivByZero {
+		t.Error("should be NaN(DivByZero)", f.Kind())
+	}
+	if f := CompoundInterest(fixed.NewS("1000"), minusOne, -2, fixed.RoundHalfEven); f.Kind() != fixed.KindDivByZero {
+		t.Error("should be NaN(DivByZero)", f.Kind())
+	}
+
+	values := fs("-1000", "300", "400", "500")
+	for _, guess := range []fixed.Fixed{minusOne, fixed.NewS("-1.5")} {
+		if f, err := IRR(values, guess, fixed.RoundHalfEven); err != ErrNoConvergence || !f.IsNaN() {
+			t.Error("should not converge", guess, f, err)
+		}
+		dates := []time.Time{date(2020, 1, 1), date(2021, 1, 1), date(2022, 1, 1), date(2023, 1, 1)}
+		if f, err := XIRR(values, dates, guess, fixed.RoundHalfEven); err != ErrNoConvergence || !f.IsNaN() {
+			t.Error("should not converge", guess, f, err)
+		}
+	}
+}
+
+func TestLargePeriods(t *testing.T) {
+	rate := fixed.NewS("0.05")
+	if f := FV(rate, 1_000_000_000, fixed.NewS("-100"), fixed.ZERO, End, fixed.RoundHalfEven); f.Kind() != fixed.KindOverflow {
+		t.Error("should be NaN(Overflow)", f, f.Kind())
+	}
+	// the payments are a perpetuity, pmt/rate
+	if f := PV(rate, math.MaxInt, fixed.NewS("-100"), fixed.ZERO, End, fixed.RoundHalfEven); f.String() != "2000" {
+		t.Error("should be equal", f, "2000")
+	}
+	if f := PMT(rate, math.MaxInt, fixed.NewS("2000"), fixed.ZERO, End, fixed.RoundHalfEven); f.String() != "-100" {
+		t.Error("should be equal", f, "-100")
+	}
+	// pv grows at exactly the rate the payments take out
+	if f := FV(rate, 1_000_000_000, fixed.NewS("-100"), fixed.NewS("2000"), End, fixed.RoundHalfEven); f.String() != "-2000" {
+		t.Error("should be equal", f, "-2000")
+	}
+	if f := CompoundInterest(fixed.NewS("1000"), fixed.NewS("-0.5"), math.MinInt, fixed.RoundHalfEven); f.Kind() != fixed.KindOverflow {
+		t.Error("should be NaN(Overflow)", f, f.Kind())
+	}
+	if f := CompoundInterest(fixed.NewS("1000"), fixed.NewS("-0.5"), math.MaxInt, fixed.RoundHalfEven); f.String() != "-1000" {
+		t.Error("should be equal", f, "-1000")
+	}
+	if f := CompoundInterest(fixed.NewS("1000"), fixed.NewS("-3"), 1_000_000_001, fixed.RoundHalfEven); f.Kind() != fixed.KindOverflow {
+		t.Error("should be NaN(Overflow)", f, f.Kind())
+	}
+}
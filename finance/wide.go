@@ -0,0 +1,217 @@
+package finance
+
+import (
+	"math/big"
+
+	"github.com/robaho/fixed"
+)
+
+// intermediate results are held as big.Int fixed point values with wideplaces decimal places, so that only the
+// final result is rounded to the 7 places of a Fixed. All operations are deterministic.
+
+const wideplaces = 40
+const fixedplaces = 7
+
+var (
+	bigOne  = big.NewInt(1)
+	bigTen  = big.NewInt(10)
+	wideOne = pow10(wideplaces)
+	wideTwo = new(big.Int).Lsh(wideOne, 1)
+	// wideToFixed is the divisor from the wide representation to the Fixed representation
+	wideToFixed = pow10(wideplaces - fixedplaces)
+	maxFixed    = new(big.Int).Sub(pow10(18), bigOne)
+	wideLn2     = lnReduced(div(wideOne, fromInt(3)))
+	// maxPower is 10^100, beyond which a power either makes a result out of range, or changes it by less than
+	// the 40 places held
+	maxPower = pow10(wideplaces + 100)
+)
+
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(bigTen, big.NewInt(int64(n)), nil)
+}
+
+func fromFixed(f fixed.Fixed) *big.Int {
+	w := big.NewInt(f.Raw())
+	return w.Mul(w, wideToFixed)
+}
+
+func fromInt(i int64) *big.Int {
+	w := big.NewInt(i)
+	return w.Mul(w, wideOne)
+}
+
+// toFixed rounds w to 7 decimal places, returning NaNOverflow if it is out of range
+func toFixed(w *big.Int, mode fixed.RoundingMode) fixed.Fixed {
+	return toFixedN(w, fixedplaces, mode)
+}
+
+// toFixedN rounds w to n decimal places, returning NaNOverflow if it is out of range
+func toFixedN(w *big.Int, n int, mode fixed.RoundingMode) fixed.Fixed {
+	q := divRound(w, pow10(wideplaces-n), mode)
+	if new(big.Int).Mul(q, pow10(fixedplaces-n)).CmpAbs(maxFixed) > 0 {
+		return fixed.NaNOverflow
+	}
+	return fixed.NewI(q.Int64(), uint(n))
+}
+
+// divRound returns n/d rounded to an integer using the rounding mode
+func divRound(n, d *big.Int, mode fixed.RoundingMode) *big.Int {
+	q, r := new(big.Int).QuoRem(n, d, new(big.Int))
+	if r.Sign() == 0 {
+		return q
+	}
+	neg := (n.Sign() < 0) != (d.Sign() < 0)
+	var up bool
+	switch mode {
+	case fixed.RoundDown:
+		up = false
+	case fixed.RoundUp:
+		up = true
+	case fixed.RoundFloor:
+		up = neg
+	case fixed.RoundCeiling:
+		up = !neg
+	default:
+		c := new(big.Int).Lsh(new(big.Int).Abs(r), 1).CmpAbs(d)
+		switch {
+		case c > 0:
+			up = true
+		case c < 0:
+			up = false
+		case mode == fixed.RoundHalfEven:
+			up = q.Bit(0) == 1
+		case mode == fixed.RoundHalfDown:
+			up = false
+		default:
+			up = true
+		}
+	}
+	if up {
+		if neg {
+			q.Sub(q, bigOne)
+		} else {
+			q.Add(q, bigOne)
+		}
+	}
+	return q
+}
+
+func add(a, b *big.Int) *big.Int {
+	return new(big.Int).Add(a, b)
+}
+
+func sub(a, b *big.Int) *big.Int {
+	return new(big.Int).Sub(a, b)
+}
+
+func mul(a, b *big.Int) *big.Int {
+	return divRound(new(big.Int).Mul(a, b), wideOne, fixed.RoundHalfEven)
+}
+
+func div(a, b *big.Int) *big.Int {
+	return divRound(new(big.Int).Mul(a, wideOne), b, fixed.RoundHalfEven)
+}
+
+// powInt returns x^n by repeated squaring. A power with a magnitude greater than maxPower is returned as maxPower with
+// its sign, so the time taken does not grow with n. x must not be zero if n is negative
+func powInt(x *big.Int, n int) *big.Int {
+	neg := x.Sign() < 0 && n&1 == 1
+	base := x
+	if n < 0 {
+		base = div(wideOne, x)
+	}
+	result := new(big.Int).Set(wideOne)
+	for un := uabs(n); un > 0; un >>= 1 {
+		if un&1 == 1 {
+			result = mul(result, base)
+		}
+		if un > 1 {
+			base = mul(base, base)
+		}
+		if result.CmpAbs(maxPower) > 0 || base.CmpAbs(maxPower) > 0 {
+			// |base| > 1, so every later power is larger still
+			result.Set(maxPower)
+			if neg {
+				result.Neg(result)
+			}
+			return result
+		}
+	}
+	return result
+}
+
+func uabs(n int) uint {
+	if n < 0 {
+		return uint(-n)
+	}
+	return uint(n)
+}
+
+// lnReduced returns 2*atanh(z), i.e. ln((1+z)/(1-z)), for |z| <= 1/3
+func lnReduced(z *big.Int) *big.Int {
+	z2 := mul(z, z)
+	sum := new(big.Int).Set(z)
+	term := z
+	for k := int64(3); ; k += 2 {
+		term = mul(term, z2)
+		t := new(big.Int).Quo(term, big.NewInt(k))
+		if t.Sign() == 0 {
+			break
+		}
+		sum.Add(sum, t)
+	}
+	return sum.Lsh(sum, 1)
+}
+
+// ln returns the natural logarithm of x, which must be positive
+func ln(x *big.Int) *big.Int {
+	var k int64
+	x = new(big.Int).Set(x)
+	for x.Cmp(wideTwo) > 0 {
+		x.Rsh(x, 1)
+		k++
+	}
+	for x.Cmp(wideOne) < 0 {
+		x.Lsh(x, 1)
+		k--
+	}
+	// x is in [1,2] so z is in [0,1/3]
+	z := div(sub(x, wideOne), add(x, wideOne))
+	result := lnReduced(z)
+	return result.Add(result, new(big.Int).Mul(wideLn2, big.NewInt(k)))
+}
+
+// exp returns e^x
+func exp(x *big.Int) *big.Int {
+	// reduce x below 2^-8 and square the result back up
+	var k int
+	r := new(big.Int).Set(x)
+	limit := new(big.Int).Rsh(wideOne, 8)
+	for r.CmpAbs(limit) > 0 {
+		r = divRound(r, big.NewInt(2), fixed.RoundHalfEven)
+		k++
+	}
+	sum := new(big.Int).Set(wideOne)
+	term := new(big.Int).Set(wideOne)
+	for n := int64(1); ; n++ {
+		term = new(big.Int).Quo(mul(term, r), big.NewInt(n))
+		if term.Sign() == 0 {
+			break
+		}
+		sum.Add(sum, term)
+	}
+	for ; k > 0; k-- {
+		sum = mul(sum, sum)
+	}
+	return sum
+}
+
+// ratToFixed rounds the exact rational r to 7 decimal places, returning NaN if it is nil or NaNOverflow if it is out
+// of range
+func ratToFixed(r *big.Rat, mode fixed.RoundingMode) fixed.Fixed {
+	if r == nil {
+		return fixed.NaN
+	}
+	f, _ := fixed.FromBigRat(r, mode)
+	return f
+}
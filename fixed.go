@@ -12,7 +12,7 @@ import (
 	"strings"
 )
 
-// Fixed is a fixed precision 38.24 number (supports 11.7 digits). It supports NaN.
+// Fixed is a fixed precision 38.24 number (supports 11.7 digits). It supports NaN and signed infinities.
 type Fixed struct {
 	fp int64
 }
@@ -41,6 +41,9 @@ func NewS(s string) Fixed {
 
 // NewSErr creates a new Fixed from a string, returning NaN, and error if the string could not be parsed
 func NewSErr(s string) (Fixed, error) {
+	if f, ok := parseSpecial(s); ok {
+		return f, nil
+	}
 	if strings.ContainsAny(s, "eE") {
 		f, err := strconv.ParseFloat(s, 64)
 		if err != nil {
@@ -48,9 +51,6 @@ func NewSErr(s string) (Fixed, error) {
 		}
 		return NewF(f), nil
 	}
-	if "NaN" == s {
-		return NaN, nil
-	}
 	period := strings.Index(s, ".")
 	var i int64
 	var f int64
@@ -84,7 +84,7 @@ func NewSErr(s string) (Fixed, error) {
 		}
 	}
 	if float64(i) > MAX {
-		return NaN, errTooLarge
+		return NaNOverflow, errTooLarge
 	}
 	return Fixed{fp: sign * (i*scale + f)}, nil
 }
@@ -111,20 +111,24 @@ func max(a, b int) int {
 	return b
 }
 
-// NewF creates a Fixed from an float64, rounding at the 8th decimal place
+// NewF creates a Fixed from an float64, rounding at the 8th decimal place. Infinities are preserved, and if f is
+// otherwise out of range, NaNOverflow is returned
 func NewF(f float64) Fixed {
 	if math.IsNaN(f) {
 		return Fixed{fp: nan}
 	}
-	if f >= MAX || f <= -MAX {
-		return NaN
+	if math.IsInf(f, 1) {
+		return PosInf
 	}
-	round := .5
-	if f < 0 {
-		round = -0.5
+	if math.IsInf(f, -1) {
+		return NegInf
 	}
-
-	return Fixed{fp: int64(f*float64(scale) + round)}
+	if f >= MAX || f <= -MAX {
+		return NaNOverflow
+	}
+	// scaling the integer and fractional parts separately avoids rounding the product of large values
+	i := math.Trunc(f)
+	return Fixed{fp: int64(i)*scale + int64(math.Round((f-i)*float64(scale)))}
 }
 
 // NewI creates a Fixed for an integer, moving the decimal point n places to the left
@@ -140,8 +144,9 @@ func NewI(i int64, n uint) Fixed {
 	return Fixed{fp: i}
 }
 
+// IsNaN returns true if f is NaN, of any kind
 func (f Fixed) IsNaN() bool {
-	return f.fp == nan
+	return f.fp > posInf || f.fp < negInf
 }
 
 func (f Fixed) IsZero() bool {
@@ -160,34 +165,43 @@ func (f Fixed) Sign() int {
 	return f.Cmp(ZERO)
 }
 
-// Float converts the Fixed to a float64
+// Float converts the Fixed to the nearest float64
 func (f Fixed) Float() float64 {
 	if f.IsNaN() {
 		return math.NaN()
 	}
+	if f.IsInf(0) {
+		return math.Inf(int(sign(f.fp)))
+	}
+	if f.fp > 1<<53 || f.fp < -1<<53 {
+		// the conversion of fp would be rounded before the division, so round the quotient exactly
+		return floatOf(f.fp)
+	}
 	return float64(f.fp) / float64(scale)
 }
 
-// Add adds f0 to f producing a Fixed. If either operand is NaN, NaN is returned
+// Add adds f0 to f producing a Fixed. If either operand is NaN, that NaN is returned. The sum of opposite infinities
+// is NaN
 func (f Fixed) Add(f0 Fixed) Fixed {
-	if f.IsNaN() || f0.IsNaN() {
-		return NaN
+	if !f.IsFinite() || !f0.IsFinite() {
+		return addSpecial(f, f0)
 	}
 	return Fixed{fp: f.fp + f0.fp}
 }
 
-// Sub subtracts f0 from f producing a Fixed. If either operand is NaN, NaN is returned
+// Sub subtracts f0 from f producing a Fixed. If either operand is NaN, that NaN is returned. The difference of
+// equal infinities is NaN
 func (f Fixed) Sub(f0 Fixed) Fixed {
-	if f.IsNaN() || f0.IsNaN() {
-		return NaN
+	if !f.IsFinite() || !f0.IsFinite() {
+		return addSpecial(f, f0.neg())
 	}
 	return Fixed{fp: f.fp - f0.fp}
 }
 
-// Abs returns the absolute value of f. If f is NaN, NaN is returned
+// Abs returns the absolute value of f. If f is NaN, f is returned
 func (f Fixed) Abs() Fixed {
 	if f.IsNaN() {
-		return NaN
+		return f
 	}
 	if f.Sign() >= 0 {
 		return f
@@ -203,10 +217,11 @@ func abs(i int64) int64 {
 	return i * -1
 }
 
-// Mul multiplies f by f0 returning a Fixed. If either operand is NaN, NaN is returned
+// Mul multiplies f by f0 returning a Fixed. If either operand is NaN, that NaN is returned. Zero times infinity is
+// NaN
 func (f Fixed) Mul(f0 Fixed) Fixed {
-	if f.IsNaN() || f0.IsNaN() {
-		return NaN
+	if !f.IsFinite() || !f0.IsFinite() {
+		return mulSpecial(f, f0)
 	}
 
 	fp_a := f.fp / scale
@@ -229,10 +244,11 @@ func (f Fixed) Mul(f0 Fixed) Fixed {
 	return Fixed{fp: result}
 }
 
-// Div divides f by f0 returning a Fixed. If either operand is NaN, NaN is returned
+// Div divides f by f0 returning a Fixed. If either operand is NaN, that NaN is returned. If f0 is zero,
+// NaNDivByZero is returned, and an infinity divided by an infinity is NaN
 func (f Fixed) Div(f0 Fixed) Fixed {
-	if f.IsNaN() || f0.IsNaN() {
-		return NaN
+	if !f.IsFinite() || !f0.IsFinite() || f0.fp == 0 {
+		return divSpecial(f, f0)
 	}
 	return NewF(f.Float() / f0.Float())
 }
@@ -244,10 +260,10 @@ func sign(fp int64) int64 {
 	return 1
 }
 
-// Round returns a rounded (half-up, away from zero) to n decimal places
+// Round returns a rounded (half-up, away from zero) to n decimal places. NaN and infinities are returned unchanged
 func (f Fixed) Round(n int) Fixed {
-	if f.IsNaN() {
-		return NaN
+	if !f.IsFinite() {
+		return f
 	}
 
 	fraction := f.fp % scale
@@ -295,7 +311,8 @@ func (f Fixed) LessThanOrEqual(f0 Fixed) bool {
 	return cmp == -1 || cmp == 0
 }
 
-// Cmp compares two Fixed. If f == f0, return 0. If f > f0, return 1. If f < f0, return -1. If both are NaN, return 0. If f is NaN, return 1. If f0 is NaN, return -1
+// Cmp compares two Fixed. If f == f0, return 0. If f > f0, return 1. If f < f0, return -1. If both are NaN, return 0. If f is NaN, return 1. If f0 is NaN, return -1.
+// Infinities compare beyond all finite values, and NaN of any kind beyond +Inf
 func (f Fixed) Cmp(f0 Fixed) int {
 	if f.IsNaN() && f0.IsNaN() {
 		return 0
@@ -351,9 +368,12 @@ func (f Fixed) tostr() (string, int) {
 	if fp == 0 {
 		return "0." + zeros, 1
 	}
-	if fp == nan {
+	if f.IsNaN() {
 		return "NaN", -1
 	}
+	if f.IsInf(0) {
+		return f.Kind().String(), -1
+	}
 
 	b := make([]byte, 24)
 	b = itoa(b, fp)
@@ -386,17 +406,17 @@ func itoa(buf []byte, val int64) []byte {
 	return buf[i:]
 }
 
-// Int return the integer portion of the Fixed, or 0 if NaN
+// Int return the integer portion of the Fixed, or 0 if NaN or infinite
 func (f Fixed) Int() int64 {
-	if f.IsNaN() {
+	if !f.IsFinite() {
 		return 0
 	}
 	return f.fp / scale
 }
 
-// Frac return the fractional portion of the Fixed, or NaN if NaN
+// Frac return the fractional portion of the Fixed, or NaN if NaN or infinite
 func (f Fixed) Frac() float64 {
-	if f.IsNaN() {
+	if !f.IsFinite() {
 		return math.NaN()
 	}
 	return float64(f.fp%scale) / float64(scale)
@@ -439,8 +459,12 @@ func (f *Fixed) UnmarshalJSON(bytes []byte) error {
 	if s == "null" {
 		return nil
 	}
-	if s == "\"NaN\"" {
-		*f = NaN
+	if len(s) >= 2 && s[0] == '"' && s[len(s)-1] == '"' {
+		special, ok := parseSpecial(s[1 : len(s)-1])
+		if !ok {
+			return fmt.Errorf("Error decoding string '%s': %s", s, errFormat)
+		}
+		*f = special
 		return nil
 	}
 
@@ -452,10 +476,11 @@ func (f *Fixed) UnmarshalJSON(bytes []byte) error {
 	return nil
 }
 
-// MarshalJSON implements the json.Marshaler interface.
+// MarshalJSON implements the json.Marshaler interface. NaN and infinities are encoded as strings, with the kind of
+// NaN, e.g. "NaN(DivByZero)"
 func (f Fixed) MarshalJSON() ([]byte, error) {
-	if f.IsNaN() {
-		return []byte("\"NaN\""), nil
+	if !f.IsFinite() {
+		return []byte("\"" + f.Kind().String() + "\""), nil
 	}
 	buffer := make([]byte, 24)
 	return itoa(buffer, f.fp), nil
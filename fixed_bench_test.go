@@ -161,6 +161,14 @@ func BenchmarkStringNFixed(b *testing.B) {
 		f0.StringN(5)
 	}
 }
+func BenchmarkAppendFixed(b *testing.B) {
+	f0 := NewF(123456789.12345)
+	buf := make([]byte, 0, 24)
+
+	for i := 0; i < b.N; i++ {
+		buf = f0.Append(buf[:0])
+	}
+}
 func BenchmarkStringDecimal(b *testing.B) {
 	f0 := decimal.NewFromFloat(123456789.12345)
 
@@ -192,3 +200,61 @@ func BenchmarkWriteTo(b *testing.B) {
 		f0.WriteTo(buf)
 	}
 }
+
+func benchSlice(n int) []Fixed {
+	s := make([]Fixed, n)
+	for i := range s {
+		s[i] = NewI(int64(i*1234567), 5)
+	}
+	return s
+}
+
+func BenchmarkAddSlices(b *testing.B) {
+	x, y := benchSlice(1024), benchSlice(1024)
+	dst := make([]Fixed, 1024)
+
+	for i := 0; i < b.N; i++ {
+		AddSlices(dst, x, y)
+	}
+}
+func BenchmarkAddLoop(b *testing.B) {
+	x, y := benchSlice(1024), benchSlice(1024)
+	dst := make([]Fixed, 1024)
+
+	for i := 0; i < b.N; i++ {
+		for j := 0; j < len(dst); j++ {
+			dst[j] = x[j].Add(y[j])
+		}
+	}
+}
+func BenchmarkMulSlices(b *testing.B) {
+	x, y := benchSlice(1024), benchSlice(1024)
+	dst := make([]Fixed, 1024)
+
+	for i := 0; i < b.N; i++ {
+		MulSlices(dst, x, y)
+	}
+}
+func BenchmarkScaleSlice(b *testing.B) {
+	x := benchSlice(1024)
+	dst := make([]Fixed, 1024)
+	k := NewS("1.0001")
+
+	for i := 0; i < b.N; i++ {
+		ScaleSlice(dst, x, k)
+	}
+}
+func BenchmarkSumSlice(b *testing.B) {
+	x := benchSlice(1024)
+
+	for i := 0; i < b.N; i++ {
+		Result = SumSlice(x)
+	}
+}
+func BenchmarkDotProduct(b *testing.B) {
+	x, y := benchSlice(1024), benchSlice(1024)
+
+	for i := 0; i < b.N; i++ {
+		Result = DotProduct(x, y)
+	}
+}
@@ -0,0 +1,305 @@
+// Package fixedcsv reads and writes CSV files of structs with fixed.Fixed fields, using encoding/csv.
+//
+// Each exported field is a column, named by the field name or the csv struct tag. The tag may also specify the
+// number of decimal places to write a Fixed with, which rounds half-up. It applies to writing only, and a Reader
+// reads values with all of their places. A field tagged "-" is ignored:
+//
+//	type Trade struct {
+//		Symbol string      `csv:"sym"`
+//		Price  fixed.Fixed `csv:"price,decimals=2"`
+//		Qty    fixed.Fixed `csv:"qty"`
+//		Note   string      `csv:"-"`
+//	}
+//
+// A blank Fixed cell is read as NaN, and NaN is written as a blank cell. Fields may also be strings, bools, integers
+// or floats. Columns are matched to fields by the header row, so their order does not matter, and unknown columns
+// are ignored.
+package fixedcsv
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/robaho/fixed"
+)
+
+var ErrUnsupportedType = errors.New("fixedcsv: unsupported type")
+var ErrMissingColumn = errors.New("fixedcsv: missing column")
+
+var fixedType = reflect.TypeOf(fixed.Fixed{})
+
+type field struct {
+	index    int
+	name     string
+	kind     reflect.Kind
+	isFixed  bool
+	decimals int
+}
+
+// fieldsOf returns the columns of the struct type t
+func fieldsOf(t reflect.Type) ([]field, error) {
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%w %s, must be a struct", ErrUnsupportedType, t)
+	}
+	var fields []field
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		tag := sf.Tag.Get("csv")
+		if tag == "-" {
+			continue
+		}
+		name, options, _ := strings.Cut(tag, ",")
+		if name == "" {
+			name = sf.Name
+		}
+		f := field{index: i, name: name, kind: sf.Type.Kind(), isFixed: sf.Type == fixedType, decimals: -1}
+		for _, option := range strings.Split(options, ",") {
+			if value, ok := strings.CutPrefix(option, "decimals="); ok {
+				n, err := strconv.Atoi(value)
+				if err != nil || n < 0 || n > 7 || !f.isFixed {
+					return nil, fmt.Errorf("fixedcsv: invalid tag %q on field %s", tag, sf.Name)
+				}
+				f.decimals = n
+			}
+		}
+		switch f.kind {
+		case reflect.String, reflect.Bool, reflect.Float32, reflect.Float64,
+			reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		default:
+			if !f.isFixed {
+				return nil, fmt.Errorf("%w %s of field %s", ErrUnsupportedType, sf.Type, sf.Name)
+			}
+		}
+		fields = append(fields, f)
+	}
+	return fields, nil
+}
+
+// Reader reads values of the struct type T from CSV with a header row
+type Reader[T any] struct {
+	r      *csv.Reader
+	fields []field
+	// columns[i] is the column of fields[i]
+	columns []int
+}
+
+// NewReader creates a Reader. An error is returned if T is not a struct, or has a field of an unsupported type
+func NewReader[T any](r io.Reader) (*Reader[T], error) {
+	fields, err := fieldsOf(reflect.TypeOf((*T)(nil)).Elem())
+	if err != nil {
+		return nil, err
+	}
+	cr := csv.NewReader(r)
+	cr.ReuseRecord = true
+	return &Reader[T]{r: cr, fields: fields}, nil
+}
+
+// CSV returns the underlying csv.Reader, to allow its options to be set before the first Read
+func (r *Reader[T]) CSV() *csv.Reader {
+	return r.r
+}
+
+func (r *Reader[T]) readHeader() error {
+	header, err := r.r.Read()
+	if err != nil {
+		return err
+	}
+	r.columns = make([]int, len(r.fields))
+	for i, f := range r.fields {
+		r.columns[i] = -1
+		for col, name := range header {
+			if strings.TrimSpace(name) == f.name {
+				r.columns[i] = col
+			}
+		}
+		if r.columns[i] < 0 {
+			return fmt.Errorf("%w %q", ErrMissingColumn, f.name)
+		}
+	}
+	return nil
+}
+
+// Read reads the next row into dst. It returns io.EOF when there are no more rows
+func (r *Reader[T]) Read(dst *T) error {
+	if r.columns == nil {
+		if err := r.readHeader(); err != nil {
+			return err
+		}
+	}
+	record, err := r.r.Read()
+	if err != nil {
+		return err
+	}
+	v := reflect.ValueOf(dst).Elem()
+	for i, f := range r.fields {
+		col := r.columns[i]
+		if col >= len(record) {
+			line, _ := r.r.FieldPos(0)
+			return fmt.Errorf("fixedcsv: line %d: %w %q", line, ErrMissingColumn, f.name)
+		}
+		if err := f.parse(v.Field(f.index), strings.TrimSpace(record[col])); err != nil {
+			line, column := r.r.FieldPos(col)
+			return fmt.Errorf("fixedcsv: line %d, column %d (%s): %w", line, column, f.name, err)
+		}
+	}
+	return nil
+}
+
+// ReadAll reads all of the remaining rows
+func (r *Reader[T]) ReadAll() ([]T, error) {
+	var rows []T
+	for {
+		var row T
+		err := r.Read(&row)
+		if err == io.EOF {
+			return rows, nil
+		}
+		if err != nil {
+			return rows, err
+		}
+		rows = append(rows, row)
+	}
+}
+
+func (f field) parse(v reflect.Value, s string) error {
+	if f.isFixed {
+		value := fixed.NaN
+		if s != "" {
+			var err error
+			if value, err = fixed.NewSErr(s); err != nil {
+				return err
+			}
+		}
+		*(v.Addr().Interface().(*fixed.Fixed)) = value
+		return nil
+	}
+	switch f.kind {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Float32, reflect.Float64:
+		x, err := strconv.ParseFloat(s, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(x)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(i)
+	default:
+		u, err := strconv.ParseUint(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(u)
+	}
+	return nil
+}
+
+// Writer writes values of the struct type T as CSV with a header row. Writing a row allocates at most one string for
+// each field which is not a string
+type Writer[T any] struct {
+	w      *csv.Writer
+	fields []field
+	header bool
+	buf    []byte
+	record []string
+}
+
+// NewWriter creates a Writer. An error is returned if T is not a struct, or has a field of an unsupported type
+func NewWriter[T any](w io.Writer) (*Writer[T], error) {
+	fields, err := fieldsOf(reflect.TypeOf((*T)(nil)).Elem())
+	if err != nil {
+		return nil, err
+	}
+	return &Writer[T]{w: csv.NewWriter(w), fields: fields, record: make([]string, len(fields))}, nil
+}
+
+// CSV returns the underlying csv.Writer, to allow its options to be set before the first Write
+func (w *Writer[T]) CSV() *csv.Writer {
+	return w.w
+}
+
+// Write writes a row, preceded by the header row if it is the first
+func (w *Writer[T]) Write(row *T) error {
+	if !w.header {
+		for i, f := range w.fields {
+			w.record[i] = f.name
+		}
+		if err := w.w.Write(w.record); err != nil {
+			return err
+		}
+		w.header = true
+	}
+	v := reflect.ValueOf(row).Elem()
+	for i, f := range w.fields {
+		fv := v.Field(f.index)
+		if f.kind == reflect.String {
+			w.record[i] = fv.String()
+			continue
+		}
+		w.buf = f.format(w.buf[:0], fv)
+		w.record[i] = string(w.buf)
+	}
+	return w.w.Write(w.record)
+}
+
+// WriteAll writes the rows and flushes the output
+func (w *Writer[T]) WriteAll(rows []T) error {
+	for i := range rows {
+		if err := w.Write(&rows[i]); err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
+
+// Flush writes any buffered data to the underlying io.Writer
+func (w *Writer[T]) Flush() {
+	w.w.Flush()
+}
+
+// Error reports any error that has occurred during a previous Write or Flush
+func (w *Writer[T]) Error() error {
+	return w.w.Error()
+}
+
+func (f field) format(buf []byte, v reflect.Value) []byte {
+	if f.isFixed {
+		value := *(v.Addr().Interface().(*fixed.Fixed))
+		switch {
+		case value.IsNaN():
+			return buf
+		case f.decimals >= 0:
+			return value.RoundTo(f.decimals, fixed.RoundHalfUp).AppendN(buf, f.decimals)
+		}
+		return value.Append(buf)
+	}
+	switch f.kind {
+	case reflect.Bool:
+		return strconv.AppendBool(buf, v.Bool())
+	case reflect.Float32, reflect.Float64:
+		return strconv.AppendFloat(buf, v.Float(), 'g', -1, v.Type().Bits())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.AppendInt(buf, v.Int(), 10)
+	}
+	return strconv.AppendUint(buf, v.Uint(), 10)
+}
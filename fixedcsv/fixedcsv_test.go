@@ -0,0 +1,135 @@
+package fixedcsv
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/robaho/fixed"
+)
+
+type trade struct {
+	Symbol string      `csv:"sym"`
+	Price  fixed.Fixed `csv:"price,decimals=2"`
+	Qty    fixed.Fixed `csv:"qty"`
+	Fee    fixed.Fixed
+	Count  int    `csv:"count"`
+	Ok     bool   `csv:"ok"`
+	Note   string `csv:"-"`
+	hidden int
+}
+
+const data = `qty,sym,price,Fee,count,ok,extra
+100,ABC,10.125,0.5,1,true,x
+2.5, XYZ ,99.999,,-3,false,y
+`
+
+func TestRead(t *testing.T) {
+	r, err := NewReader[trade](strings.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rows, err := r.ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 2 {
+		t.Fatal("wrong number of rows", len(rows))
+	}
+	// decimals applies to writing only
+	row := rows[0]
+	if row.Symbol != "ABC" || row.Price.String() != "10.125" || row.Qty.String() != "100" || row.Fee.String() != "0.5" ||
+		row.Count != 1 || !row.Ok {
+		t.Error("wrong row", row)
+	}
+	row = rows[1]
+	if row.Symbol != "XYZ" || row.Price.String() != "99.999" || !row.Fee.IsNaN() || row.Count != -3 || row.Ok {
+		t.Error("wrong row", row)
+	}
+}
+
+func TestReadErrors(t *testing.T) {
+	r, _ := NewReader[trade](strings.NewReader("sym,price,qty,Fee,count\nABC,1,1,1,1\n"))
+	var row trade
+	if err := r.Read(&row); !errors.Is(err, ErrMissingColumn) {
+		t.Error("should be a missing column", err)
+	}
+
+	r, _ = NewReader[trade](strings.NewReader("sym,price,qty,Fee,count,ok\nABC,1,1,1,1,true\nABC,bad,1,1,1,true\n"))
+	if err := r.Read(&row); err != nil {
+		t.Fatal(err)
+	}
+	err := r.Read(&row)
+	if err == nil || err.Error() != "fixedcsv: line 3, column 5 (price): cannot parse" {
+		t.Error("wrong error", err)
+	}
+	if err := r.Read(&row); err != io.EOF {
+		t.Error("should be EOF", err)
+	}
+
+	if _, err := NewReader[int](strings.NewReader("")); !errors.Is(err, ErrUnsupportedType) {
+		t.Error("should be unsupported", err)
+	}
+	type bad struct {
+		M map[string]int
+	}
+	if _, err := NewWriter[bad](io.Discard); !errors.Is(err, ErrUnsupportedType) {
+		t.Error("should be unsupported", err)
+	}
+	type badTag struct {
+		S string `csv:"s,decimals=2"`
+	}
+	if _, err := NewWriter[badTag](io.Discard); err == nil {
+		t.Error("should be an invalid tag")
+	}
+}
+
+func TestWrite(t *testing.T) {
+	rows := []trade{
+		{Symbol: "ABC", Price: fixed.NewS("10.125"), Qty: fixed.NewS("100"), Fee: fixed.NewS("0.5"), Count: 1, Ok: true},
+		{Symbol: "X,Y", Price: fixed.NewS("-3"), Qty: fixed.NewS("2.5"), Fee: fixed.NaN, Count: -3, Note: "ignored"},
+	}
+	var buf bytes.Buffer
+	w, err := NewWriter[trade](&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := w.WriteAll(rows); err != nil {
+		t.Fatal(err)
+	}
+	want := `sym,price,qty,Fee,count,ok
+ABC,10.13,100,0.5,1,true
+"X,Y",-3.00,2.5,,-3,false
+`
+	if buf.String() != want {
+		t.Error("wrong output", buf.String())
+	}
+
+	// round trip, apart from the rounding of price
+	r, _ := NewReader[trade](&buf)
+	read, err := r.ReadAll()
+	if err != nil || len(read) != 2 || read[0].Price.String() != "10.13" || !read[1].Fee.IsNaN() || read[1].Symbol != "X,Y" {
+		t.Error("wrong round trip", read, err)
+	}
+}
+
+func TestWriteAllocs(t *testing.T) {
+	type quote struct {
+		Bid  fixed.Fixed `csv:"bid,decimals=4"`
+		Ask  fixed.Fixed `csv:"ask"`
+		Size int64       `csv:"size"`
+		Sym  string      `csv:"sym"`
+	}
+	w, _ := NewWriter[quote](io.Discard)
+	row := quote{Bid: fixed.NewS("123.45678"), Ask: fixed.NewS("123.5"), Size: 100, Sym: "ABC"}
+	_ = w.Write(&row)
+	allocs := testing.AllocsPerRun(100, func() {
+		_ = w.Write(&row)
+	})
+	// one string for each of bid, ask and size, and none for sym
+	if allocs > 3 {
+		t.Error("should allocate at most 3", allocs)
+	}
+}
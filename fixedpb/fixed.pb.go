@@ -0,0 +1,221 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        (unknown)
+// source: fixed.proto
+
+package fixedpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Fixed is a fixed.Fixed as a scaled integer. Without a scale, raw is the internal representation returned by
+// Fixed.Raw, i.e. the value scaled by 10^7, which includes the values reserved for NaN and the infinities. With a
+// scale, the value is raw * 10^-scale, and must be finite.
+type Fixed struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Raw   int64  `protobuf:"varint,1,opt,name=raw,proto3" json:"raw,omitempty"`
+	Scale *int32 `protobuf:"varint,2,opt,name=scale,proto3,oneof" json:"scale,omitempty"`
+}
+
+func (x *Fixed) Reset() {
+	*x = Fixed{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_fixed_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Fixed) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Fixed) ProtoMessage() {}
+
+func (x *Fixed) ProtoReflect() protoreflect.Message {
+	mi := &file_fixed_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Fixed.ProtoReflect.Descriptor instead.
+func (*Fixed) Descriptor() ([]byte, []int) {
+	return file_fixed_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Fixed) GetRaw() int64 {
+	if x != nil {
+		return x.Raw
+	}
+	return 0
+}
+
+func (x *Fixed) GetScale() int32 {
+	if x != nil && x.Scale != nil {
+		return *x.Scale
+	}
+	return 0
+}
+
+// Decimal is a decimal number in string form, e.g. "-123.45" or "1.5e3". It is wire compatible with
+// google.type.Decimal, so either message may be used to decode the other.
+type Decimal struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Value string `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (x *Decimal) Reset() {
+	*x = Decimal{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_fixed_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Decimal) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Decimal) ProtoMessage() {}
+
+func (x *Decimal) ProtoReflect() protoreflect.Message {
+	mi := &file_fixed_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Decimal.ProtoReflect.Descriptor instead.
+func (*Decimal) Descriptor() ([]byte, []int) {
+	return file_fixed_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Decimal) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+var File_fixed_proto protoreflect.FileDescriptor
+
+var file_fixed_proto_rawDesc = []byte{
+	0x0a, 0x0b, 0x66, 0x69, 0x78, 0x65, 0x64, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0c, 0x72,
+	0x6f, 0x62, 0x61, 0x68, 0x6f, 0x2e, 0x66, 0x69, 0x78, 0x65, 0x64, 0x22, 0x3e, 0x0a, 0x05, 0x46,
+	0x69, 0x78, 0x65, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x72, 0x61, 0x77, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x03, 0x72, 0x61, 0x77, 0x12, 0x19, 0x0a, 0x05, 0x73, 0x63, 0x61, 0x6c, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x05, 0x48, 0x00, 0x52, 0x05, 0x73, 0x63, 0x61, 0x6c, 0x65, 0x88, 0x01,
+	0x01, 0x42, 0x08, 0x0a, 0x06, 0x5f, 0x73, 0x63, 0x61, 0x6c, 0x65, 0x22, 0x1f, 0x0a, 0x07, 0x44,
+	0x65, 0x63, 0x69, 0x6d, 0x61, 0x6c, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x42, 0x21, 0x5a, 0x1f,
+	0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x72, 0x6f, 0x62, 0x61, 0x68,
+	0x6f, 0x2f, 0x66, 0x69, 0x78, 0x65, 0x64, 0x2f, 0x66, 0x69, 0x78, 0x65, 0x64, 0x70, 0x62, 0x62,
+	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_fixed_proto_rawDescOnce sync.Once
+	file_fixed_proto_rawDescData = file_fixed_proto_rawDesc
+)
+
+func file_fixed_proto_rawDescGZIP() []byte {
+	file_fixed_proto_rawDescOnce.Do(func() {
+		file_fixed_proto_rawDescData = protoimpl.X.CompressGZIP(file_fixed_proto_rawDescData)
+	})
+	return file_fixed_proto_rawDescData
+}
+
+var file_fixed_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_fixed_proto_goTypes = []any{
+	(*Fixed)(nil),   // 0: robaho.fixed.Fixed
+	(*Decimal)(nil), // 1: robaho.fixed.Decimal
+}
+var file_fixed_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_fixed_proto_init() }
+func file_fixed_proto_init() {
+	if File_fixed_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_fixed_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*Fixed); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_fixed_proto_msgTypes[1].Exporter = func(v any, i int) any {
+			switch v := v.(*Decimal); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_fixed_proto_msgTypes[0].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_fixed_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_fixed_proto_goTypes,
+		DependencyIndexes: file_fixed_proto_depIdxs,
+		MessageInfos:      file_fixed_proto_msgTypes,
+	}.Build()
+	File_fixed_proto = out.File
+	file_fixed_proto_rawDesc = nil
+	file_fixed_proto_goTypes = nil
+	file_fixed_proto_depIdxs = nil
+}
@@ -0,0 +1,140 @@
+// Package fixedpb converts fixed.Fixed to and from Protocol Buffers messages, defined in fixed.proto.
+//
+// The Fixed message carries the internal representation, which is compact and exact, and preserves NaN and the
+// infinities. ToProtoScaled encodes with a different number of decimal places, for peers using another scale. The
+// Decimal message carries the string form, and is wire compatible with google.type.Decimal.
+//
+// A nil message, or a Decimal with an empty value, decodes as NaN, as a missing value.
+package fixedpb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative fixed.proto
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/robaho/fixed"
+)
+
+var ErrSyntax = errors.New("fixedpb: invalid decimal")
+
+const places = 7
+
+// ToProto returns the Fixed message for f
+func ToProto(f fixed.Fixed) *Fixed {
+	return &Fixed{Raw: f.Raw()}
+}
+
+// ToProtoScaled returns the Fixed message for f with scale decimal places, rounding using mode. fixed.ErrNaN is
+// returned if f is NaN, and fixed.ErrOverflow if it is infinite or the scaled value overflows an int64
+func ToProtoScaled(f fixed.Fixed, scale int32, mode fixed.RoundingMode) (*Fixed, error) {
+	raw, err := f.ToScaled(-int(scale), mode)
+	if err != nil {
+		return nil, err
+	}
+	return &Fixed{Raw: raw, Scale: &scale}, nil
+}
+
+// FromProto returns the Fixed for the message p. fixed.ErrOverflow is returned if the value is out of range, and
+// fixed.ErrPrecision if it has more than 7 decimal places
+func FromProto(p *Fixed) (fixed.Fixed, error) {
+	if p == nil {
+		return fixed.NaN, nil
+	}
+	if p.Scale == nil {
+		if f := fixed.FromRaw(p.Raw); !f.IsFinite() {
+			return f, nil
+		}
+		return fixed.FromScaled(p.Raw, -places)
+	}
+	return fixed.FromScaled(p.Raw, -int(*p.Scale))
+}
+
+// ToDecimal returns the Decimal message for f. The string form of google.type.Decimal cannot represent NaN or
+// infinities, so fixed.ErrNaN is returned if f is NaN, and fixed.ErrOverflow if it is infinite
+func ToDecimal(f fixed.Fixed) (*Decimal, error) {
+	if f.IsNaN() {
+		return nil, fixed.ErrNaN
+	}
+	if f.IsInf(0) {
+		return nil, fixed.ErrOverflow
+	}
+	return &Decimal{Value: f.String()}, nil
+}
+
+// FromDecimal returns the Fixed for the message d, which is parsed exactly using the google.type.Decimal syntax: an
+// optional sign, digits with an optional decimal point, and an optional exponent. ErrSyntax is returned if the value
+// is invalid, fixed.ErrOverflow if it is out of range, and fixed.ErrPrecision if it has more than 7 decimal places
+func FromDecimal(d *Decimal) (fixed.Fixed, error) {
+	if d == nil || d.Value == "" {
+		return fixed.NaN, nil
+	}
+	return parseDecimal(d.Value)
+}
+
+func parseDecimal(s string) (fixed.Fixed, error) {
+	neg := false
+	if s[0] == '+' || s[0] == '-' {
+		neg = s[0] == '-'
+		s = s[1:]
+	}
+	mantissa, exponent, hasExp := strings.Cut(s, "e")
+	if !hasExp {
+		mantissa, exponent, hasExp = strings.Cut(s, "E")
+	}
+	intPart, fracPart, _ := strings.Cut(mantissa, ".")
+	if intPart == "" && fracPart == "" || !isDigits(intPart) || !isDigits(fracPart) {
+		return fixed.NaN, ErrSyntax
+	}
+	exp := 0
+	if hasExp {
+		unsigned := exponent
+		if unsigned != "" && (unsigned[0] == '+' || unsigned[0] == '-') {
+			unsigned = unsigned[1:]
+		}
+		if unsigned == "" || !isDigits(unsigned) {
+			return fixed.NaN, ErrSyntax
+		}
+		e, err := strconv.ParseInt(exponent, 10, 32)
+		if err != nil {
+			// a huge exponent is out of range, or too precise, unless the value is zero
+			e = 1 << 30
+			if exponent[0] == '-' {
+				e = -e
+			}
+		}
+		exp = int(e)
+	}
+
+	// the significant digits, and the exponent of the last of them
+	digits := strings.TrimLeft(intPart+fracPart, "0")
+	exp -= len(fracPart)
+	trimmed := strings.TrimRight(digits, "0")
+	exp += len(digits) - len(trimmed)
+	digits = trimmed
+	if digits == "" {
+		return fixed.ZERO, nil
+	}
+	if len(digits) > 18 {
+		// more digits than a Fixed holds
+		if exp < -places {
+			return fixed.NaN, fixed.ErrPrecision
+		}
+		return fixed.NaNOverflow, fixed.ErrOverflow
+	}
+	v, _ := strconv.ParseInt(digits, 10, 64)
+	if neg {
+		v = -v
+	}
+	return fixed.FromScaled(v, exp)
+}
+
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
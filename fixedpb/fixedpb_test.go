@@ -0,0 +1,149 @@
+package fixedpb
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/robaho/fixed"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestProtoRoundTrip(t *testing.T) {
+	values := []fixed.Fixed{fixed.ZERO, fixed.NewS("123.456"), fixed.NewS("-0.0000001"), fixed.NewS("99999999999.9999999"),
+		fixed.NaN, fixed.NaNDivByZero, fixed.NaNOverflow, fixed.PosInf, fixed.NegInf}
+	for _, f := range values {
+		b, err := proto.Marshal(ToProto(f))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var p Fixed
+		if err := proto.Unmarshal(b, &p); err != nil {
+			t.Fatal(err)
+		}
+		f0, err := FromProto(&p)
+		if err != nil || f0.Kind() != f.Kind() || f.IsFinite() && !f0.Equal(f) {
+			t.Error("should be equal", f0, f, err)
+		}
+	}
+	if f, err := FromProto(nil); err != nil || !f.IsNaN() {
+		t.Error("should be NaN", f, err)
+	}
+	if _, err := FromProto(&Fixed{Raw: 1 << 62}); err != fixed.ErrOverflow {
+		t.Error("should be overflow", err)
+	}
+}
+
+func TestProtoScaled(t *testing.T) {
+	p, err := ToProtoScaled(fixed.NewS("123.455"), 2, fixed.RoundHalfEven)
+	if err != nil || p.Raw != 12346 || p.GetScale() != 2 {
+		t.Error("wrong message", p, err)
+	}
+	b, _ := proto.Marshal(p)
+	var p0 Fixed
+	_ = proto.Unmarshal(b, &p0)
+	if f, err := FromProto(&p0); err != nil || f.String() != "123.46" {
+		t.Error("should be equal", f, "123.46", err)
+	}
+
+	scale := int32(-3)
+	if f, err := FromProto(&Fixed{Raw: 12, Scale: &scale}); err != nil || f.String() != "12000" {
+		t.Error("should be equal", f, "12000", err)
+	}
+	scale = 9
+	if _, err := FromProto(&Fixed{Raw: 123, Scale: &scale}); err != fixed.ErrPrecision {
+		t.Error("should be precision", err)
+	}
+	// with a scale, the reserved values are not special
+	scale = 7
+	if _, err := FromProto(&Fixed{Raw: fixed.NaN.Raw(), Scale: &scale}); err != fixed.ErrOverflow {
+		t.Error("should be overflow", err)
+	}
+	if _, err := ToProtoScaled(fixed.NaN, 2, fixed.RoundHalfUp); err != fixed.ErrNaN {
+		t.Error("should be NaN", err)
+	}
+	if _, err := ToProtoScaled(fixed.NewI(1, 0), 19, fixed.RoundHalfUp); err != fixed.ErrOverflow {
+		t.Error("should be overflow", err)
+	}
+}
+
+func TestDecimal(t *testing.T) {
+	testCases := []struct {
+		in  string
+		out string
+		err error
+	}{
+		{"123.456", "123.456", nil},
+		{"+123.456", "123.456", nil},
+		{"-0.0000001", "-0.0000001", nil},
+		{"0", "0", nil},
+		{"-0.000", "0", nil},
+		{".5", "0.5", nil},
+		{"5.", "5", nil},
+		{"1.5e3", "1500", nil},
+		{"15E-7", "0.0000015", nil},
+		{"1.5e-7", "", fixed.ErrPrecision},
+		{"0001.230000000000000000000000", "1.23", nil},
+		{"12300000000000000000000e-18", "12300", nil},
+		{"99999999999.9999999", "99999999999.9999999", nil},
+		{"100000000000", "", fixed.ErrOverflow},
+		{"1234567890123456789", "", fixed.ErrOverflow},
+		{"1.234567890123456789", "", fixed.ErrPrecision},
+		{"1e99999999999", "", fixed.ErrOverflow},
+		{"1e-99999999999", "", fixed.ErrPrecision},
+		{"0e99999999999", "0", nil},
+		{"0.00000001", "", fixed.ErrPrecision},
+		{"abc", "", ErrSyntax},
+		{"-", "", ErrSyntax},
+		{".", "", ErrSyntax},
+		{"1e", "", ErrSyntax},
+		{"1e+-2", "", ErrSyntax},
+		{"1.2.3", "", ErrSyntax},
+		{"NaN", "", ErrSyntax},
+		{"Inf", "", ErrSyntax},
+		{" 1", "", ErrSyntax},
+	}
+	for _, tc := range testCases {
+		f, err := FromDecimal(&Decimal{Value: tc.in})
+		if !errors.Is(err, tc.err) {
+			t.Error("unexpected error", tc.in, err)
+			continue
+		}
+		if err == nil && f.String() != tc.out {
+			t.Error("should be equal", tc.in, f, tc.out)
+		}
+	}
+	if f, err := FromDecimal(&Decimal{}); err != nil || !f.IsNaN() {
+		t.Error("should be NaN", f, err)
+	}
+	if f, err := FromDecimal(nil); err != nil || !f.IsNaN() {
+		t.Error("should be NaN", f, err)
+	}
+}
+
+func TestDecimalRoundTrip(t *testing.T) {
+	for _, s := range []string{"0", "1", "-1.5", "0.0000001", "-99999999999.9999999", "123456.789"} {
+		d, err := ToDecimal(fixed.NewS(s))
+		if err != nil || d.Value != s {
+			t.Error("should be equal", d, s, err)
+		}
+		b, _ := proto.Marshal(d)
+		// the encoding of a google.type.Decimal, a string in field 1
+		want := protowire.AppendString(protowire.AppendTag(nil, 1, protowire.BytesType), s)
+		if !bytes.Equal(b, want) {
+			t.Error("should be equal", b, want)
+		}
+		var d0 Decimal
+		_ = proto.Unmarshal(b, &d0)
+		if f, err := FromDecimal(&d0); err != nil || f.String() != s {
+			t.Error("should be equal", f, s, err)
+		}
+	}
+	if _, err := ToDecimal(fixed.NaNDivByZero); err != fixed.ErrNaN {
+		t.Error("should be NaN", err)
+	}
+	if _, err := ToDecimal(fixed.NegInf); err != fixed.ErrOverflow {
+		t.Error("should be overflow", err)
+	}
+}
@@ -0,0 +1,101 @@
+package fixed
+
+import (
+	"math"
+	"math/bits"
+	"strconv"
+)
+
+// NewFExact creates a Fixed from a float64 using the shortest decimal representation that converts back to the same
+// float64, as produced by strconv, rounded half-up to 7 decimal places. Unlike NewF this is not affected by binary
+// representation error, e.g. 0.1+0.2 is 0.3 and 1.00000005 is 1.0000001. NewFExact(f.Float()) == f for all f with an
+// absolute value less than 2^52/10^7 (about 4.5e8), beyond which a float64 cannot hold 7 decimal places. NaN and
+// infinities are preserved, and if f is otherwise out of range, NaNOverflow is returned
+func NewFExact(f float64) Fixed {
+	return newFShortest(f, 64)
+}
+
+// NewF32 creates a Fixed from a float32 using the shortest decimal representation that converts back to the same
+// float32, rounded half-up to 7 decimal places. NaN and infinities are preserved, and if f is otherwise out of range,
+// NaNOverflow is returned
+func NewF32(f float32) Fixed {
+	return newFShortest(float64(f), 32)
+}
+
+func newFShortest(f float64, bitSize int) Fixed {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return NewF(f)
+	}
+	if f >= MAX || f <= -MAX {
+		return NaNOverflow
+	}
+	var buf [32]byte
+	b := strconv.AppendFloat(buf[:0], f, 'e', -1, bitSize)
+
+	// b is [-]d[.ddd]e±dd with at most 17 significant digits
+	neg := b[0] == '-'
+	if neg {
+		b = b[1:]
+	}
+	var m int64
+	var digits int
+	i := 0
+	for ; b[i] != 'e'; i++ {
+		if b[i] != '.' {
+			m = m*10 + int64(b[i]-'0')
+			digits++
+		}
+	}
+	exp, _ := strconv.Atoi(string(b[i+1:]))
+	if neg {
+		m = -m
+	}
+
+	// the value is m*10^(exp-digits+1), and is rescaled to 10^-7 units
+	n := exp - digits + 1 + nPlaces
+	if n >= 0 {
+		fp, ok := mulPow10(m, n)
+		if !ok || fp > maxFP || fp < -maxFP {
+			return NaNOverflow
+		}
+		return Fixed{fp: fp}
+	}
+	if -n >= len(pow10) {
+		// m has at most 17 digits so is less than half of 10^-n
+		return ZERO
+	}
+	fp, _ := mulDiv(m, 1, pow10[-n], RoundHalfUp)
+	return Fixed{fp: fp}
+}
+
+// floatOf returns fp/scale rounded to the nearest float64, for an fp with a magnitude greater than 2^53
+func floatOf(fp int64) float64 {
+	u := uabs(fp)
+	// the integer and fractional parts convert exactly, so the estimate is within one unit in the last place
+	c := float64(u/uint64(scale)) + float64(u%uint64(scale))/float64(scale)
+	// c is m*2^-k, so compare u/scale with the midpoints between c and its neighbours exactly, as u*2^(k+2) against
+	// the midpoints in units of 2^-(k+2) multiplied by scale. There are no ties, as a midpoint has more factors of 2
+	// than u/scale
+	frac, exp := math.Frexp(c)
+	m := uint64(frac * (1 << 53))
+	k := uint(53 - exp)
+	xhi, xlo := bits.Mul64(u, 1<<(k+2))
+	above := func(mid uint64) bool {
+		hi, lo := bits.Mul64(mid, uint64(scale))
+		return xhi > hi || (xhi == hi && xlo > lo)
+	}
+	below := 4*m - 2
+	if m == 1<<52 {
+		// the neighbour below c is half as far away
+		below = 4*m - 1
+	}
+	if above(4*m + 2) {
+		c = math.Nextafter(c, math.Inf(1))
+	} else if !above(below) {
+		c = math.Nextafter(c, 0)
+	}
+	if fp < 0 {
+		return -c
+	}
+	return c
+}
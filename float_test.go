@@ -0,0 +1,134 @@
+package fixed_test
+
+import (
+	"math"
+	"math/rand"
+	"strconv"
+	"testing"
+
+	. "github.com/robaho/fixed"
+)
+
+func TestNewFExact(t *testing.T) {
+	testCases := []struct {
+		in  float64
+		out string
+	}{
+		{0.1 + 0.2, "0.3"},
+		{1.0000005, "1.0000005"},
+		{1.00000005, "1.0000001"},
+		{-1.00000005, "-1.0000001"},
+		{2.675, "2.675"},
+		{123456.789, "123456.789"},
+		{1e-300, "0"},
+		{5e-8, "0.0000001"},
+		{4.9e-8, "0"},
+		{0, "0"},
+		{12345678901, "12345678901"},
+		{99999999999.99998, "99999999999.99998"},
+		{1e11, "NaN"},
+		{-1e11, "NaN"},
+		{math.Inf(1), "+Inf"},
+		{math.Inf(-1), "-Inf"},
+		{math.NaN(), "NaN"},
+	}
+	for _, tc := range testCases {
+		if f := NewFExact(tc.in); f.String() != tc.out {
+			t.Error("should be equal", tc.in, f, tc.out)
+		}
+	}
+	// NewF is subject to the binary representation error
+	if f := NewF(1.00000005); f.String() != "1" {
+		t.Error("should be equal", f, "1")
+	}
+}
+
+func TestNewF32(t *testing.T) {
+	testCases := []struct {
+		in  float32
+		out string
+	}{
+		{0.1, "0.1"},
+		{123456.79, "123456.79"},
+		{1.0000001, "1.0000001"},
+		{-3.5, "-3.5"},
+		{1e20, "NaN"},
+		{float32(math.NaN()), "NaN"},
+	}
+	for _, tc := range testCases {
+		if f := NewF32(tc.in); f.String() != tc.out {
+			t.Error("should be equal", tc.in, f, tc.out)
+		}
+	}
+	// the float64 value of the float32 has more digits
+	if f := NewF(float64(float32(123456.79))); f.String() != "123456.7890625" {
+		t.Error("should be equal", f, "123456.7890625")
+	}
+}
+
+func TestFloatRoundTrip(t *testing.T) {
+	// below 2^52 units of 10^-7 a float64 holds every value with 7 decimal places exactly enough to round trip
+	const limit = 1 << 52
+	r := rand.New(rand.NewSource(1))
+	check := func(raw int64) {
+		f := FromRaw(raw)
+		if g := NewF(f.Float()); !g.Equal(f) {
+			t.Error("NewF should round trip", f, g)
+		}
+		if g := NewFExact(f.Float()); !g.Equal(f) {
+			t.Error("NewFExact should round trip", f, g)
+		}
+	}
+	for _, raw := range []int64{0, 1, -1, limit - 1, -(limit - 1), 10000000, 5, 4999999} {
+		check(raw)
+	}
+	for i := 0; i < 100000; i++ {
+		// uniformly distributed over the number of digits
+		raw := r.Int63n(limit) >> uint(r.Intn(52))
+		if r.Intn(2) == 0 {
+			raw = -raw
+		}
+		check(raw)
+	}
+	// beyond that the float64 is the closest to the value, so converting back is the identity
+	for i := 0; i < 100000; i++ {
+		f := FromRaw(limit + r.Int63n(999999999999999999-limit))
+		if g := NewF(f.Float()); g.Float() != f.Float() {
+			t.Error("NewF should round trip", f, g)
+		}
+		if g := NewFExact(f.Float()); g.Float() != f.Float() {
+			t.Error("NewFExact should round trip", f, g)
+		}
+	}
+}
+
+func TestFloatLarge(t *testing.T) {
+	// beyond 2^53 units of 10^-7 the conversion is rounded once, as parsing the decimal form is
+	check := func(raw int64) {
+		f := FromRaw(raw)
+		want, _ := strconv.ParseFloat(f.String(), 64)
+		if f.Float() != want {
+			t.Error("should be equal", f, f.Float(), want)
+		}
+		if f = FromRaw(-raw); f.Float() != -want {
+			t.Error("should be equal", f, f.Float(), -want)
+		}
+	}
+	for _, raw := range []int64{1<<53 + 1, 999999999999999999, 1 << 62 / 10} {
+		check(raw)
+	}
+	// around powers of 2, where the spacing of float64 values changes
+	for e := uint(30); e <= 36; e++ {
+		for d := int64(-1000); d <= 1000; d++ {
+			check(int64(1<<e)*10000000 + d)
+		}
+	}
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 100000; i++ {
+		check(1<<53 + r.Int63n(999999999999999999-1<<53))
+	}
+	f := FromRaw(999999999999999999)
+	if allocs := testing.AllocsPerRun(100, func() { _ = f.Float() }); allocs != 0 {
+		t.Error("should not allocate", allocs)
+	}
+}
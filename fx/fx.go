@@ -0,0 +1,240 @@
+// Package fx provides foreign exchange rate tables and currency conversion using fixed.Fixed.
+//
+// A rate for the pair BASE/QUOTE is the number of units of QUOTE per unit of BASE, e.g. EUR/USD 1.0842.
+// Inverse rates are derived from the quoted pair, and cross rates are triangulated through the table's
+// base currency. Derived bids are always rounded down and derived asks up, so a derived spread is never
+// narrower than the quotes it came from.
+package fx
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/robaho/fixed"
+)
+
+// Side selects which side of a Rate is used for a conversion
+type Side int
+
+const (
+	Bid Side = iota
+	Ask
+	Mid
+)
+
+// DefaultMinorUnits is the number of decimal places used for a currency with no minor units configured
+const DefaultMinorUnits = 2
+
+var ErrNoRate = errors.New("fx: no rate")
+var ErrInvalidRate = errors.New("fx: invalid rate")
+
+var one = fixed.NewI(1, 0)
+var two = fixed.NewI(2, 0)
+
+// Rate is a two sided quote
+type Rate struct {
+	Bid fixed.Fixed `json:"bid"`
+	Ask fixed.Fixed `json:"ask"`
+}
+
+// NewRate creates a Rate with the same bid and ask
+func NewRate(r fixed.Fixed) Rate {
+	return Rate{Bid: r, Ask: r}
+}
+
+// Mid returns the mid point of the bid and ask, rounded half-even
+func (r Rate) Mid() fixed.Fixed {
+	return r.Bid.Add(r.Ask).DivRound(two, 7, fixed.RoundHalfEven)
+}
+
+// Side returns the bid, ask or mid of the rate
+func (r Rate) Side(side Side) fixed.Fixed {
+	switch side {
+	case Bid:
+		return r.Bid
+	case Ask:
+		return r.Ask
+	}
+	return r.Mid()
+}
+
+// Inverse returns the rate for the reversed pair. The bid is the reciprocal of the ask, rounded down, and the
+// ask the reciprocal of the bid, rounded up
+func (r Rate) Inverse() Rate {
+	return Rate{
+		Bid: one.DivRound(r.Ask, 7, fixed.RoundDown),
+		Ask: one.DivRound(r.Bid, 7, fixed.RoundUp),
+	}
+}
+
+// Cross returns the rate A/C given r as A/B and r0 as B/C
+func (r Rate) Cross(r0 Rate) Rate {
+	return Rate{
+		Bid: r.Bid.MulRound(r0.Bid, 7, fixed.RoundDown),
+		Ask: r.Ask.MulRound(r0.Ask, 7, fixed.RoundUp),
+	}
+}
+
+func (r Rate) valid() bool {
+	if !r.Bid.IsFinite() || !r.Ask.IsFinite() {
+		return false
+	}
+	return r.Bid.Sign() > 0 && r.Bid.LessThanOrEqual(r.Ask)
+}
+
+type pair struct {
+	base, quote string
+}
+
+// RateTable holds quoted rates and derives inverse and cross rates through a base currency. A RateTable is not
+// safe for concurrent modification.
+type RateTable struct {
+	base  string
+	rates map[pair]Rate
+	minor map[string]int
+	// Mode is the rounding applied when converting an amount to the minor units of the target currency
+	Mode fixed.RoundingMode
+}
+
+// NewRateTable creates an empty RateTable which triangulates cross rates through the base currency
+func NewRateTable(base string) *RateTable {
+	return &RateTable{
+		base:  base,
+		rates: make(map[pair]Rate),
+		minor: make(map[string]int),
+		Mode:  fixed.RoundHalfEven,
+	}
+}
+
+// Base returns the currency used to derive cross rates
+func (t *RateTable) Base() string {
+	return t.base
+}
+
+// Set adds or replaces the quote for base/quote. The bid must be positive and not greater than the ask
+func (t *RateTable) Set(base, quote string, rate Rate) error {
+	if !rate.valid() || base == quote {
+		return fmt.Errorf("%w %s/%s %v/%v", ErrInvalidRate, base, quote, rate.Bid, rate.Ask)
+	}
+	t.rates[pair{base, quote}] = rate
+	return nil
+}
+
+// SetMinorUnits sets the number of decimal places amounts in currency are rounded to by Convert
+func (t *RateTable) SetMinorUnits(currency string, n int) {
+	t.minor[currency] = n
+}
+
+// MinorUnits returns the number of decimal places used for currency, DefaultMinorUnits if not set
+func (t *RateTable) MinorUnits(currency string) int {
+	if n, ok := t.minor[currency]; ok {
+		return n
+	}
+	return DefaultMinorUnits
+}
+
+func (t *RateTable) lookup(from, to string) (Rate, bool) {
+	if from == to {
+		return NewRate(one), true
+	}
+	if r, ok := t.rates[pair{from, to}]; ok {
+		return r, true
+	}
+	if r, ok := t.rates[pair{to, from}]; ok {
+		return r.Inverse(), true
+	}
+	return Rate{}, false
+}
+
+// Rate returns the rate for from/to, using a quoted rate, the inverse of a quoted rate, or a cross rate
+// through the base currency, in that order
+func (t *RateTable) Rate(from, to string) (Rate, error) {
+	if r, ok := t.lookup(from, to); ok {
+		return r, nil
+	}
+	if from != t.base && to != t.base {
+		r0, ok0 := t.lookup(from, t.base)
+		r1, ok1 := t.lookup(t.base, to)
+		if ok0 && ok1 {
+			return r0.Cross(r1), nil
+		}
+	}
+	return Rate{}, fmt.Errorf("%w %s/%s", ErrNoRate, from, to)
+}
+
+// Convert converts an amount in currency from to currency to, using the specified side of the from/to rate. The
+// exact product is rounded once to the minor units of the target currency using the table's Mode
+func (t *RateTable) Convert(amount fixed.Fixed, from, to string, side Side) (fixed.Fixed, error) {
+	r, err := t.Rate(from, to)
+	if err != nil {
+		return fixed.NaN, err
+	}
+	return amount.MulRound(r.Side(side), t.MinorUnits(to), t.Mode), nil
+}
+
+// LoadCSV reads rates with the columns base,quote,bid,ask. If the ask column is omitted the bid is used for
+// both sides. A header row starting with "base" is skipped
+func (t *RateTable) LoadCSV(r io.Reader) error {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+	for line := 1; ; line++ {
+		record, err := cr.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if line == 1 && strings.EqualFold(record[0], "base") {
+			continue
+		}
+		if len(record) < 3 || len(record) > 4 {
+			return fmt.Errorf("fx: line %d: expected 3 or 4 fields, got %d", line, len(record))
+		}
+		var rate Rate
+		if rate.Bid, err = fixed.Parse(record[2]); err != nil {
+			return fmt.Errorf("fx: line %d: %w", line, err)
+		}
+		rate.Ask = rate.Bid
+		if len(record) == 4 {
+			if rate.Ask, err = fixed.Parse(record[3]); err != nil {
+				return fmt.Errorf("fx: line %d: %w", line, err)
+			}
+		}
+		if err = t.Set(record[0], record[1], rate); err != nil {
+			return fmt.Errorf("fx: line %d: %w", line, err)
+		}
+	}
+}
+
+type jsonRate struct {
+	Base  string      `json:"base"`
+	Quote string      `json:"quote"`
+	Bid   fixed.Fixed `json:"bid"`
+	Ask   fixed.Fixed `json:"ask"`
+	Rate  fixed.Fixed `json:"rate"`
+}
+
+// LoadJSON reads an array of rates in the form {"base":"EUR","quote":"USD","bid":1.0841,"ask":1.0843}. A single
+// "rate" may be given instead of the bid and ask
+func (t *RateTable) LoadJSON(r io.Reader) error {
+	var rates []jsonRate
+	if err := json.NewDecoder(r).Decode(&rates); err != nil {
+		return err
+	}
+	for _, jr := range rates {
+		rate := Rate{Bid: jr.Bid, Ask: jr.Ask}
+		if !jr.Rate.IsZero() {
+			rate = NewRate(jr.Rate)
+		}
+		if err := t.Set(jr.Base, jr.Quote, rate); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,149 @@
+package fx
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/robaho/fixed"
+)
+
+func newTable(t *testing.T) *RateTable {
+	table := NewRateTable("USD")
+	err := table.LoadCSV(strings.NewReader(`base,quote,bid,ask
+EUR,USD,1.0841,1.0843
+USD,JPY,149.50,149.52
+GBP,USD,1.2650
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	table.SetMinorUnits("JPY", 0)
+	return table
+}
+
+func TestRateDirectAndInverse(t *testing.T) {
+	table := newTable(t)
+
+	r, err := table.Rate("EUR", "USD")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Bid.String() != "1.0841" || r.Ask.String() != "1.0843" {
+		t.Error("wrong direct rate", r)
+	}
+	if r.Mid().String() != "1.0842" {
+		t.Error("should be equal", r.Mid(), "1.0842")
+	}
+
+	r, err = table.Rate("USD", "EUR")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Bid.String() != "0.9222539" || r.Ask.String() != "0.9224242" {
+		t.Error("wrong inverse rate", r)
+	}
+
+	r, err = table.Rate("USD", "USD")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Bid.String() != "1" || r.Ask.String() != "1" {
+		t.Error("wrong identity rate", r)
+	}
+}
+
+func TestRateCross(t *testing.T) {
+	table := newTable(t)
+
+	r, err := table.Rate("EUR", "JPY")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Bid.String() != "162.07295" || r.Ask.String() != "162.124536" {
+		t.Error("wrong cross rate", r)
+	}
+	if r.Bid.GreaterThan(r.Ask) {
+		t.Error("bid should not exceed ask", r)
+	}
+
+	r, err = table.Rate("EUR", "GBP")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Bid.String() != "0.856996" || r.Ask.String() != "0.8571543" {
+		t.Error("wrong cross rate", r)
+	}
+
+	_, err = table.Rate("EUR", "CHF")
+	if !errors.Is(err, ErrNoRate) {
+		t.Error("expected ErrNoRate", err)
+	}
+}
+
+func TestConvert(t *testing.T) {
+	table := newTable(t)
+
+	amount, err := table.Convert(fixed.NewS("1000"), "EUR", "USD", Bid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if amount.String() != "1084.1" {
+		t.Error("should be equal", amount, "1084.1")
+	}
+
+	amount, err = table.Convert(fixed.NewS("1234.56"), "EUR", "JPY", Ask)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if amount.String() != "200152" {
+		t.Error("should be equal", amount, "200152")
+	}
+
+	table.SetMinorUnits("USD", 2)
+	table.Mode = fixed.RoundHalfEven
+	amount, _ = table.Convert(fixed.NewS("0.125"), "GBP", "USD", Mid)
+	if amount.String() != "0.16" {
+		t.Error("should be equal", amount, "0.16")
+	}
+	table.Mode = fixed.RoundHalfUp
+	amount, _ = table.Convert(fixed.NewS("0.125"), "GBP", "USD", Mid)
+	if amount.String() != "0.16" {
+		t.Error("should be equal", amount, "0.16")
+	}
+	amount, _ = table.Convert(fixed.NewS("0.1"), "EUR", "USD", Mid)
+	if amount.String() != "0.11" {
+		t.Error("should be equal", amount, "0.11")
+	}
+}
+
+func TestLoadJSON(t *testing.T) {
+	table := NewRateTable("USD")
+	err := table.LoadJSON(strings.NewReader(`[
+		{"base":"EUR","quote":"USD","bid":1.0841,"ask":1.0843},
+		{"base":"USD","quote":"CHF","rate":0.8812}
+	]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := table.Rate("EUR", "CHF")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Bid.String() != "0.9553089" || r.Ask.String() != "0.9554852" {
+		t.Error("wrong cross rate", r)
+	}
+}
+
+func TestInvalidRates(t *testing.T) {
+	table := NewRateTable("USD")
+	if err := table.Set("EUR", "USD", Rate{Bid: fixed.NewS("1.1"), Ask: fixed.NewS("1.0")}); !errors.Is(err, ErrInvalidRate) {
+		t.Error("expected ErrInvalidRate", err)
+	}
+	if err := table.Set("EUR", "USD", NewRate(fixed.NaN)); !errors.Is(err, ErrInvalidRate) {
+		t.Error("expected ErrInvalidRate", err)
+	}
+	if err := table.LoadCSV(strings.NewReader("EUR,USD,abc\n")); err == nil {
+		t.Error("expected error")
+	}
+}
@@ -0,0 +1,252 @@
+package fixed
+
+import "math/bits"
+
+// IEEE 754-2008 decimal floating point, decimal64 and decimal128, in either the binary integer decimal (BID) or the
+// densely packed decimal (DPD) encoding of the coefficient. Like Decompose, these expose the value as a coefficient
+// and exponent. A finite value is encoded with the trailing zeros of its fraction removed, e.g. 1.5 as 15E-1. NaN and
+// the infinities map to their decimal equivalents, and the kind of a NaN is carried in its payload.
+
+// DecimalEncoding is the encoding of the coefficient of an IEEE 754 decimal floating point number
+type DecimalEncoding int
+
+const (
+	// BID is the binary integer decimal encoding, used by Intel and BSON
+	BID DecimalEncoding = iota
+	// DPD is the densely packed decimal encoding, used by IBM
+	DPD
+)
+
+const (
+	d64Bias      = 398
+	d64MaxCoeff  = 9999999999999999
+	d64ExpBits   = 8  // the bits of the exponent continuation
+	d128ExpBits  = 12 // the bits of the exponent continuation
+	d64Declets   = 5
+	d128Declets  = 11
+	d64LargeForm = 3 << 61 // the BID combination bits of a coefficient of 2^53 or more
+	// the combination bits that mark an infinity or NaN. The high bits of the sign, NaN and infinities are the same
+	// for decimal64 and decimal128
+	decSpecial = d128Inf
+)
+
+// ToDecimal64 returns the decimal64 encoding of f. A decimal64 holds 16 significant digits, so ErrPrecision is
+// returned if f has more
+func (f Fixed) ToDecimal64(enc DecimalEncoding) (uint64, error) {
+	switch {
+	case f.IsNaN() && enc == DPD:
+		_, payload := dpdEncode(f.nanPayload(), d64Declets)
+		return d128NaN | payload, nil
+	case f.IsNaN():
+		return d128NaN | f.nanPayload(), nil
+	case f.IsInf(1):
+		return d128Inf, nil
+	case f.IsInf(-1):
+		return d128Sign | d128Inf, nil
+	}
+	m, exp := f.trimZeros()
+	var d uint64
+	if m < 0 {
+		d = d128Sign
+	}
+	c := uabs(m)
+	if c > d64MaxCoeff {
+		return 0, ErrPrecision
+	}
+	e := uint64(exp + d64Bias)
+	if enc == DPD {
+		lead, cont := dpdEncode(c, d64Declets)
+		return d | dpdCombination(lead, e, d64ExpBits)<<(64-6-d64ExpBits) | cont, nil
+	}
+	if c >= 1<<53 {
+		return d | d64LargeForm | e<<51 | c&(1<<51-1), nil
+	}
+	return d | e<<53 | c, nil
+}
+
+// FromDecimal64 returns the Fixed for the decimal64 d. ErrOverflow is returned if the value is out of range, and
+// ErrPrecision if it has more than 7 decimal places
+func FromDecimal64(d uint64, enc DecimalEncoding) (Fixed, error) {
+	neg := d&d128Sign != 0
+	switch {
+	case d&d128NaN == d128NaN:
+		if enc == DPD {
+			return nanOfPayload(dpdDecode(0, d, d64Declets)), nil
+		}
+		return nanOfPayload(d & (1<<50 - 1)), nil
+	case d&d128NaN == decSpecial:
+		if neg {
+			return NegInf, nil
+		}
+		return PosInf, nil
+	}
+	var c uint64
+	var e int
+	switch {
+	case enc == DPD:
+		lead, biased := dpdSplit(d>>(64-6-d64ExpBits)&(1<<(5+d64ExpBits)-1), d64ExpBits)
+		c = lead*pow10u(3*d64Declets) + dpdDecode(0, d, d64Declets)
+		e = int(biased)
+	case d&d64LargeForm == d64LargeForm:
+		e = int(d >> 51 & (1<<10 - 1))
+		c = 1<<53 | d&(1<<51-1)
+		if c > d64MaxCoeff {
+			// non-canonical
+			c = 0
+		}
+	default:
+		e = int(d >> 53 & (1<<10 - 1))
+		c = d & (1<<53 - 1)
+	}
+	return fromCoefficient(neg, 0, c, e-d64Bias)
+}
+
+// ToDecimal128 returns the high and low 64 bits of the decimal128 encoding of f. Every finite Fixed is exactly
+// representable
+func (f Fixed) ToDecimal128(enc DecimalEncoding) (hi, lo uint64) {
+	if enc == BID || !f.IsFinite() && !f.IsNaN() {
+		return f.toBID128()
+	}
+	if f.IsNaN() {
+		_, cont := dpdEncode(f.nanPayload(), d64Declets)
+		return d128NaN, cont
+	}
+	m, exp := f.trimZeros()
+	if m < 0 {
+		hi = d128Sign
+	}
+	// the coefficient has at most 18 digits, so only the lowest 6 declets are used, which are in the low 64 bits
+	_, lo = dpdEncode(uabs(m), 6)
+	return hi | dpdCombination(0, uint64(exp+d128Bias), d128ExpBits)<<(64-6-d128ExpBits), lo
+}
+
+// FromDecimal128 returns the Fixed for the decimal128 with high and low 64 bits hi and lo. ErrOverflow is returned
+// if the value is out of range, and ErrPrecision if it has more than 7 decimal places
+func FromDecimal128(hi, lo uint64, enc DecimalEncoding) (Fixed, error) {
+	if enc == BID || hi&decSpecial == decSpecial {
+		if enc == DPD && hi&d128NaN == d128NaN {
+			return nanOfPayload(dpdDecode(0, lo, 1)), nil
+		}
+		return fromBID128(hi, lo)
+	}
+	neg := hi&d128Sign != 0
+	lead, biased := dpdSplit(hi>>(64-6-d128ExpBits)&(1<<(5+d128ExpBits)-1), d128ExpBits)
+	// the coefficient is the leading digit followed by 11 declets, accumulated in 128 bits
+	chi, clo := uint64(0), lead
+	for i := d128Declets - 1; i >= 0; i-- {
+		declet := dpdDeclet(hi, lo, i)
+		h, l := bits.Mul64(clo, 1000)
+		var carry uint64
+		clo, carry = bits.Add64(l, declet, 0)
+		chi = chi*1000 + h + carry
+	}
+	return fromCoefficient(neg, chi, clo, int(biased)-d128Bias)
+}
+
+func pow10u(n int) uint64 {
+	return uint64(pow10[n])
+}
+
+// dpdCombination returns the 5 bit combination field followed by the expBits bits of the exponent continuation, which
+// together encode the leading digit lead and the biased exponent e
+func dpdCombination(lead, e uint64, expBits int) uint64 {
+	msb, cont := e>>expBits, e&(1<<expBits-1)
+	if lead < 8 {
+		return (msb<<3|lead)<<expBits | cont
+	}
+	return (3<<3|msb<<1|lead&1)<<expBits | cont
+}
+
+// dpdSplit returns the leading digit and biased exponent encoded by the combination field and exponent continuation,
+// the inverse of dpdCombination
+func dpdSplit(bits uint64, expBits int) (lead, e uint64) {
+	comb, cont := bits>>expBits, bits&(1<<expBits-1)
+	if comb>>3 != 3 {
+		return comb & 7, comb>>3<<expBits | cont
+	}
+	return 8 + comb&1, comb>>1&3<<expBits | cont
+}
+
+// dpdEncode returns the DPD encoding of the n lowest groups of 3 digits of c in the low bits, and the value of the
+// remaining digits
+func dpdEncode(c uint64, n int) (rest, encoded uint64) {
+	for i := 0; i < n; i++ {
+		encoded |= dpdFromDigits(c%1000) << (10 * i)
+		c /= 1000
+	}
+	return c, encoded
+}
+
+// dpdDecode returns the value of the n lowest declets of the 128 bits hi and lo
+func dpdDecode(hi, lo uint64, n int) uint64 {
+	var c uint64
+	for i := n - 1; i >= 0; i-- {
+		c = c*1000 + dpdDeclet(hi, lo, i)
+	}
+	return c
+}
+
+// dpdDeclet returns the value of the i'th declet of the 128 bits hi and lo
+func dpdDeclet(hi, lo uint64, i int) uint64 {
+	shift := 10 * i
+	var v uint64
+	switch {
+	case shift >= 64:
+		v = hi >> (shift - 64)
+	case shift > 54:
+		v = lo>>shift | hi<<(64-shift)
+	default:
+		v = lo >> shift
+	}
+	return dpdToDigits(v & 0x3ff)
+}
+
+// dpdFromDigits returns the declet that encodes the 3 digit value v
+func dpdFromDigits(v uint64) uint64 {
+	d2, d1, d0 := v/100, v/10%10, v%10
+	switch d2>>3<<2 | d1>>3<<1 | d0>>3 {
+	case 0:
+		return d2<<7 | d1<<4 | d0
+	case 1:
+		return d2<<7 | d1<<4 | 0x8 | d0&1
+	case 2:
+		return d2<<7 | d0&6<<4 | d1&1<<4 | 0xa | d0&1
+	case 3:
+		return d2<<7 | 0x40 | d1&1<<4 | 0xe | d0&1
+	case 4:
+		return d0&6<<7 | d2&1<<7 | d1<<4 | 0xc | d0&1
+	case 5:
+		return d1&6<<7 | d2&1<<7 | 0x20 | d1&1<<4 | 0xe | d0&1
+	case 6:
+		return d0&6<<7 | d2&1<<7 | d1&1<<4 | 0xe | d0&1
+	}
+	return d2&1<<7 | 0x60 | d1&1<<4 | 0xe | d0&1
+}
+
+// dpdToDigits returns the 3 digit value encoded by the declet d. The non-canonical declets decode as their canonical
+// equivalents
+func dpdToDigits(d uint64) uint64 {
+	pqr, stu, wxy := d>>7, d>>4&7, d&7
+	if d&0x8 == 0 {
+		return pqr*100 + stu*10 + wxy
+	}
+	y, u, r := d&1, d>>4&1, d>>7&1
+	pq := d >> 8 & 3
+	switch d >> 1 & 3 {
+	case 0:
+		return pqr*100 + stu*10 + 8 + y
+	case 1:
+		return pqr*100 + (8+u)*10 + stu>>1<<1 + y
+	case 2:
+		return (8+r)*100 + stu*10 + pq<<1 + y
+	}
+	switch d >> 5 & 3 {
+	case 0:
+		return (8+r)*100 + (8+u)*10 + pq<<1 + y
+	case 1:
+		return (8+r)*100 + (pq<<1+u)*10 + 8 + y
+	case 2:
+		return pqr*100 + (8+u)*10 + 8 + y
+	}
+	return (8+r)*100 + (8+u)*10 + 8 + y
+}
@@ -0,0 +1,163 @@
+package fixed_test
+
+import (
+	"math/rand"
+	"testing"
+
+	. "github.com/robaho/fixed"
+)
+
+func TestDecimal64(t *testing.T) {
+	testCases := []struct {
+		in       string
+		bid, dpd uint64
+	}{
+		{"0", 0x31c0000000000000, 0x2238000000000000},
+		{"1", 0x31c0000000000001, 0x2238000000000001},
+		{"-7.5", 0xb1a000000000004b, 0xa234000000000075},
+		{"0.0000001", 0x30e0000000000001, 0x221c000000000001},
+		{"999999999.9999999", 0x6c3b86f26fc0ffff, 0x6e1cff3fcff3fcff},
+		{"123456789.1234567", 0x30e462d53c9baf07, 0x261d34b9c1f4d2e7},
+		{"NaN", 0x7c00000000000000, 0x7c00000000000000},
+		{"+Inf", 0x7800000000000000, 0x7800000000000000},
+		{"-Inf", 0xf800000000000000, 0xf800000000000000},
+	}
+	for _, tc := range testCases {
+		f := NewS(tc.in)
+		for _, enc := range []struct {
+			enc  DecimalEncoding
+			bits uint64
+		}{{BID, tc.bid}, {DPD, tc.dpd}} {
+			d, err := f.ToDecimal64(enc.enc)
+			if err != nil || d != enc.bits {
+				t.Errorf("wrong encoding %s %d %#x %#x %v", tc.in, enc.enc, d, enc.bits, err)
+			}
+			f0, err := FromDecimal64(d, enc.enc)
+			if err != nil || f0.String() != tc.in {
+				t.Error("should be equal", f0, tc.in, err)
+			}
+		}
+	}
+	if _, err := NewS("12345678901.2345678").ToDecimal64(BID); err != ErrPrecision {
+		t.Error("should be precision", err)
+	}
+	// 9999999999999999E+369, the largest decimal64, and 1E-398, the smallest
+	if _, err := FromDecimal64(0x77fcff3fcff3fcff, DPD); err != ErrOverflow {
+		t.Error("should be overflow", err)
+	}
+	if _, err := FromDecimal64(0x77fb86f26fc0ffff, BID); err != ErrOverflow {
+		t.Error("should be overflow", err)
+	}
+	if _, err := FromDecimal64(0x0000000000000001, DPD); err != ErrPrecision {
+		t.Error("should be precision", err)
+	}
+	// -7.50 with a trailing zero, and a non-canonical BID coefficient, which is zero
+	if f, err := FromDecimal64(0xa2300000000003d0, DPD); err != nil || f.String() != "-7.5" {
+		t.Error("should be equal", f, "-7.5", err)
+	}
+	if f, err := FromDecimal64(0x6ff0000000000000|(1<<51-1), BID); err != nil || !f.Equal(ZERO) {
+		t.Error("should be zero", f, err)
+	}
+}
+
+func TestDecimal128(t *testing.T) {
+	testCases := []struct {
+		in           string
+		bidHi, bidLo uint64
+		dpdHi, dpdLo uint64
+	}{
+		{"0", 0x3040000000000000, 0, 0x2208000000000000, 0},
+		{"-1", 0xb040000000000000, 1, 0xa208000000000000, 1},
+		{"-7.5", 0xb03e000000000000, 75, 0xa207c00000000000, 0x75},
+		{"99999999999.9999999", 0x3032000000000000, 999999999999999999, 0x2206400000000000, 0x03fcff3fcff3fcff},
+		{"NaN", 0x7c00000000000000, 0, 0x7c00000000000000, 0},
+		{"-Inf", 0xf800000000000000, 0, 0xf800000000000000, 0},
+	}
+	for _, tc := range testCases {
+		f := NewS(tc.in)
+		hi, lo := f.ToDecimal128(BID)
+		if hi != tc.bidHi || lo != tc.bidLo {
+			t.Errorf("wrong encoding %s %#x %#x", tc.in, hi, lo)
+		}
+		if f0, err := FromDecimal128(hi, lo, BID); err != nil || f0.String() != tc.in {
+			t.Error("should be equal", f0, tc.in, err)
+		}
+		hi, lo = f.ToDecimal128(DPD)
+		if hi != tc.dpdHi || lo != tc.dpdLo {
+			t.Errorf("wrong encoding %s %#x %#x", tc.in, hi, lo)
+		}
+		if f0, err := FromDecimal128(hi, lo, DPD); err != nil || f0.String() != tc.in {
+			t.Error("should be equal", f0, tc.in, err)
+		}
+	}
+	// 9999999999999999999999999999999999E+6111, the largest decimal128, with all 11 declets
+	if _, err := FromDecimal128(0x77ffcff3fcff3fcf, 0xf3fcff3fcff3fcff, DPD); err != ErrOverflow {
+		t.Error("should be overflow", err)
+	}
+	// 1.000000000000000000000000000000000, with 33 trailing zeros
+	if f, err := FromDecimal128(0x25ffc00000000000, 0, DPD); err != nil || f.String() != "1" {
+		t.Error("should be equal", f, "1", err)
+	}
+}
+
+func TestDecimalRoundTrip(t *testing.T) {
+	for _, nan := range []Fixed{NaN, NaNDivByZero, NaNOverflow} {
+		for _, enc := range []DecimalEncoding{BID, DPD} {
+			d, _ := nan.ToDecimal64(enc)
+			f64, _ := FromDecimal64(d, enc)
+			hi, lo := nan.ToDecimal128(enc)
+			f128, _ := FromDecimal128(hi, lo, enc)
+			if f64.Kind() != nan.Kind() || f128.Kind() != nan.Kind() {
+				t.Error("should be equal", f64.Kind(), f128.Kind(), nan.Kind())
+			}
+		}
+	}
+	check := func(f Fixed) {
+		if f.IsNaN() {
+			// out of range
+			return
+		}
+		for _, enc := range []DecimalEncoding{BID, DPD} {
+			hi, lo := f.ToDecimal128(enc)
+			if f0, err := FromDecimal128(hi, lo, enc); err != nil || !f0.Equal(f) {
+				t.Error("should be equal", f0, f, enc, err)
+			}
+			d, err := f.ToDecimal64(enc)
+			if err == ErrPrecision {
+				continue
+			}
+			if f0, err := FromDecimal64(d, enc); err != nil || !f0.Equal(f) {
+				t.Error("should be equal", f0, f, enc, err)
+			}
+		}
+	}
+	// every 3 digit group, in each position
+	for v := int64(0); v < 1000; v++ {
+		for _, exp := range []int{-7, -4, 0, 3, 6, 9} {
+			f, _ := FromScaled(v, exp)
+			check(f)
+			f, _ = FromScaled(v*1000000+1, exp-6)
+			check(ZERO.Sub(f))
+		}
+	}
+	for i := 0; i < 100000; i++ {
+		check(FromRaw(rand.Int63n(2*999999999999999999) - 999999999999999999))
+	}
+}
+
+func TestDecimalNonCanonicalDeclets(t *testing.T) {
+	// each declet decodes to a 3 digit value, and all but 24 are the canonical encoding of it
+	nonCanonical := 0
+	for declet := uint64(0); declet < 1024; declet++ {
+		f, err := FromDecimal64(0x2238000000000000|declet, DPD)
+		if err != nil || f.Cmp(NewI(999, 0)) > 0 || f.Sign() < 0 {
+			t.Error("wrong value", declet, f, err)
+		}
+		if d, _ := f.ToDecimal64(DPD); d&0x3ff != declet {
+			nonCanonical++
+		}
+	}
+	if nonCanonical != 24 {
+		t.Error("should be equal", nonCanonical, 24)
+	}
+}
@@ -0,0 +1,146 @@
+package fixed
+
+import "math/bits"
+
+// int128 is a signed 128 bit integer, used to accumulate sums and products of the internal representation
+// without intermediate overflow
+type int128 struct {
+	hi int64
+	lo uint64
+}
+
+func int128From(i int64) int128 {
+	return int128{hi: i >> 63, lo: uint64(i)}
+}
+
+// mul128 returns the exact product a*b
+func mul128(a, b int64) int128 {
+	hi, lo := bits.Mul64(uabs(a), uabs(b))
+	p := int128{hi: int64(hi), lo: lo}
+	if (a < 0) != (b < 0) {
+		return p.neg()
+	}
+	return p
+}
+
+func (a int128) neg() int128 {
+	lo, borrow := bits.Sub64(0, a.lo, 0)
+	return int128{hi: -a.hi - int64(borrow), lo: lo}
+}
+
+func (a int128) isNeg() bool {
+	return a.hi < 0
+}
+
+// add returns a+b, and false if the result overflowed
+func (a int128) add(b int128) (int128, bool) {
+	lo, carry := bits.Add64(a.lo, b.lo, 0)
+	hi := a.hi + b.hi + int64(carry)
+	ok := (a.hi < 0) != (b.hi < 0) || (hi < 0) == (a.hi < 0)
+	return int128{hi: hi, lo: lo}, ok
+}
+
+// abs returns the magnitude of a as an unsigned 128 bit value
+func (a int128) abs() (hi, lo uint64) {
+	if a.isNeg() {
+		a = a.neg()
+	}
+	return uint64(a.hi), a.lo
+}
+
+// int64 returns a as an int64, and false if it is out of range
+func (a int128) int64() (int64, bool) {
+	if a.hi != int64(a.lo)>>63 {
+		return 0, false
+	}
+	return int64(a.lo), true
+}
+
+// divRound returns a/d rounded according to mode, and false if d is zero or the result is larger than maxFP
+func (a int128) divRound(d int64, mode RoundingMode) (int64, bool) {
+	if d == 0 {
+		return 0, false
+	}
+	neg := a.isNeg() != (d < 0)
+	hi, lo := a.abs()
+	ud := uabs(d)
+	if hi >= ud {
+		return 0, false
+	}
+	q, r := bits.Div64(hi, lo, ud)
+	if roundUp(q, r, ud, neg, mode) {
+		q++
+	}
+	if q > uint64(maxFP) {
+		return 0, false
+	}
+	if neg {
+		return -int64(q), true
+	}
+	return int64(q), true
+}
+
+// uint256 is an unsigned 256 bit integer, least significant word first, used to accumulate sums of squares of the
+// internal representation exactly
+type uint256 [4]uint64
+
+func uint256From(hi, lo uint64) uint256 {
+	return uint256{lo, hi}
+}
+
+// add returns a+b, which must not overflow
+func (a uint256) add(b uint256) uint256 {
+	var c uint64
+	for i := range a {
+		a[i], c = bits.Add64(a[i], b[i], c)
+	}
+	return a
+}
+
+// sub returns a-b, which must not be negative
+func (a uint256) sub(b uint256) uint256 {
+	var c uint64
+	for i := range a {
+		a[i], c = bits.Sub64(a[i], b[i], c)
+	}
+	return a
+}
+
+// mul returns a*m, which must not overflow
+func (a uint256) mul(m uint64) uint256 {
+	var carry uint64
+	for i := range a {
+		hi, lo := bits.Mul64(a[i], m)
+		var c uint64
+		a[i], c = bits.Add64(lo, carry, 0)
+		carry = hi + c
+	}
+	return a
+}
+
+// mul256 returns the exact product of the unsigned 128 bit values ahi:alo and bhi:blo
+func mul256(ahi, alo, bhi, blo uint64) uint256 {
+	p := uint256From(0, alo).mul(blo)
+	p = p.add(shift64(uint256From(0, ahi).mul(blo)))
+	p = p.add(shift64(uint256From(0, alo).mul(bhi)))
+	return p.add(shift64(shift64(uint256From(0, ahi).mul(bhi))))
+}
+
+// shift64 returns a shifted left by 64 bits
+func shift64(a uint256) uint256 {
+	return uint256{0, a[0], a[1], a[2]}
+}
+
+// div returns a/d rounded down, and the remainder. d must not be zero
+func (a uint256) div(d uint64) (uint256, uint64) {
+	var r uint64
+	for i := len(a) - 1; i >= 0; i-- {
+		a[i], r = bits.Div64(r, a[i], d)
+	}
+	return a, r
+}
+
+// uint128 returns a as an unsigned 128 bit value, and false if it is out of range
+func (a uint256) uint128() (hi, lo uint64, ok bool) {
+	return a[1], a[0], a[2] == 0 && a[3] == 0
+}
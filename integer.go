@@ -0,0 +1,64 @@
+package fixed
+
+import "math"
+
+// Integer is the set of Go integer types
+type Integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// maxInt is the largest integer value that is in range
+const maxInt = maxFP / scale
+
+// FromInt creates a Fixed with the integer value i. If i is out of range, NaNOverflow is returned
+func FromInt(i int64) Fixed {
+	if i > maxInt || i < -maxInt {
+		return NaNOverflow
+	}
+	return Fixed{fp: i * scale}
+}
+
+// FromUint64 creates a Fixed with the integer value u. If u is out of range, NaNOverflow is returned
+func FromUint64(u uint64) Fixed {
+	if u > uint64(maxInt) {
+		return NaNOverflow
+	}
+	return Fixed{fp: int64(u) * scale}
+}
+
+// From creates a Fixed with the value of an integer of any type. If i is out of range, NaNOverflow is returned
+func From[T Integer](i T) Fixed {
+	if i < 0 {
+		return FromInt(int64(i))
+	}
+	return FromUint64(uint64(i))
+}
+
+// ToInt64 returns f rounded to an integer using mode. It returns false if f is NaN or infinite
+func (f Fixed) ToInt64(mode RoundingMode) (int64, bool) {
+	if !f.IsFinite() {
+		return 0, false
+	}
+	i, _ := mulDiv(f.fp, 1, scale, mode)
+	return i, true
+}
+
+// ToInt32 returns f rounded to an integer using mode. It returns false if f is NaN or infinite, or the result does
+// not fit in an int32
+func (f Fixed) ToInt32(mode RoundingMode) (int32, bool) {
+	i, ok := f.ToInt64(mode)
+	if !ok || i > math.MaxInt32 || i < math.MinInt32 {
+		return 0, false
+	}
+	return int32(i), true
+}
+
+// ToUint64 returns f rounded to an integer using mode. It returns false if f is NaN or infinite, or the result is
+// negative
+func (f Fixed) ToUint64(mode RoundingMode) (uint64, bool) {
+	i, ok := f.ToInt64(mode)
+	if !ok || i < 0 {
+		return 0, false
+	}
+	return uint64(i), true
+}
@@ -0,0 +1,93 @@
+package fixed_test
+
+import (
+	"math"
+	"testing"
+
+	. "github.com/robaho/fixed"
+)
+
+func TestFromInt(t *testing.T) {
+	if f := FromInt(-123); f.String() != "-123" {
+		t.Error("should be equal", f, "-123")
+	}
+	if f := FromInt(99999999999); f.String() != "99999999999" {
+		t.Error("should be equal", f, "99999999999")
+	}
+	if f := FromInt(100000000000); !f.IsNaN() {
+		t.Error("should be NaN", f)
+	}
+	if f := FromInt(math.MinInt64); !f.IsNaN() {
+		t.Error("should be NaN", f)
+	}
+	if f := FromUint64(42); f.String() != "42" {
+		t.Error("should be equal", f, "42")
+	}
+	if f := FromUint64(math.MaxUint64); !f.IsNaN() {
+		t.Error("should be NaN", f)
+	}
+}
+
+func TestFromGeneric(t *testing.T) {
+	type Qty uint32
+	if f := From(int8(-128)); f.String() != "-128" {
+		t.Error("should be equal", f, "-128")
+	}
+	if f := From(uint32(math.MaxUint32)); f.String() != "4294967295" {
+		t.Error("should be equal", f, "4294967295")
+	}
+	if f := From(Qty(500)); f.String() != "500" {
+		t.Error("should be equal", f, "500")
+	}
+	if f := From(uint64(math.MaxUint64)); !f.IsNaN() {
+		t.Error("should be NaN", f)
+	}
+	if f := From(int64(math.MinInt64)); !f.IsNaN() {
+		t.Error("should be NaN", f)
+	}
+}
+
+func TestToInt(t *testing.T) {
+	testCases := []struct {
+		in   string
+		mode RoundingMode
+		out  int64
+	}{
+		{"2.5", RoundHalfUp, 3},
+		{"2.5", RoundHalfEven, 2},
+		{"-2.5", RoundHalfUp, -3},
+		{"-2.5", RoundDown, -2},
+		{"-2.1", RoundFloor, -3},
+		{"2.1", RoundCeiling, 3},
+		{"99999999999.9999999", RoundHalfUp, 100000000000},
+	}
+	for _, tc := range testCases {
+		i, ok := NewS(tc.in).ToInt64(tc.mode)
+		if !ok || i != tc.out {
+			t.Error("should be equal", tc.in, i, ok, tc.out)
+		}
+	}
+	if _, ok := NaN.ToInt64(RoundHalfUp); ok {
+		t.Error("should fail for NaN")
+	}
+
+	if i, ok := NewS("-2147483648.4").ToInt32(RoundHalfUp); !ok || i != math.MinInt32 {
+		t.Error("should be equal", i, ok, math.MinInt32)
+	}
+	if _, ok := NewS("2147483647.5").ToInt32(RoundHalfUp); ok {
+		t.Error("should overflow")
+	}
+	if _, ok := NaN.ToInt32(RoundHalfUp); ok {
+		t.Error("should fail for NaN")
+	}
+
+	if u, ok := NewS("-0.4").ToUint64(RoundHalfUp); !ok || u != 0 {
+		t.Error("should be equal", u, ok, 0)
+	}
+	if _, ok := NewS("-0.4").ToUint64(RoundFloor); ok {
+		t.Error("should be negative")
+	}
+	if u, ok := NewS("12345678901.2").ToUint64(RoundDown); !ok || u != 12345678901 {
+		t.Error("should be equal", u, ok, 12345678901)
+	}
+}
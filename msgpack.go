@@ -0,0 +1,38 @@
+package fixed
+
+import "encoding/binary"
+
+// MessagePack encoding, compatible with the Marshaler and Unmarshaler interfaces of github.com/vmihailenco/msgpack,
+// which write and read the encoded value as is, so no extension needs to be registered.
+
+// MsgpackExtType is the MessagePack extension type used to encode a Fixed. An application that uses the type for
+// something else may change it during initialization, before any values are encoded or decoded
+var MsgpackExtType int8 = 1
+
+const (
+	msgpackNil     = 0xc0
+	msgpackFixExt8 = 0xd7
+)
+
+// MarshalMsgpack implements the msgpack.Marshaler interface. f is encoded as a fixext 8 extension of type
+// MsgpackExtType, carrying Raw as a big-endian int64, so NaN kinds and the infinities are preserved
+func (f Fixed) MarshalMsgpack() ([]byte, error) {
+	data := make([]byte, 10)
+	data[0] = msgpackFixExt8
+	data[1] = byte(MsgpackExtType)
+	binary.BigEndian.PutUint64(data[2:], uint64(f.fp))
+	return data, nil
+}
+
+// UnmarshalMsgpack implements the msgpack.Unmarshaler interface, decoding the extension written by MarshalMsgpack.
+// As with JSON null, nil leaves f unchanged
+func (f *Fixed) UnmarshalMsgpack(data []byte) error {
+	if len(data) == 1 && data[0] == msgpackNil {
+		return nil
+	}
+	if len(data) != 10 || data[0] != msgpackFixExt8 || int8(data[1]) != MsgpackExtType {
+		return errFormat
+	}
+	f.fp = int64(binary.BigEndian.Uint64(data[2:]))
+	return nil
+}
@@ -0,0 +1,60 @@
+package fixed_test
+
+import (
+	"bytes"
+	"testing"
+
+	. "github.com/robaho/fixed"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+func TestMsgpack(t *testing.T) {
+	for _, f := range []Fixed{ZERO, NewS("123.456"), NewS("-99999999999.9999999"), NaN, NaNDivByZero, PosInf, NegInf} {
+		data, err := f.MarshalMsgpack()
+		if err != nil || len(data) != 10 || data[0] != 0xd7 || data[1] != 1 {
+			t.Error("wrong encoding", f, data, err)
+		}
+		var f0 Fixed
+		if err := f0.UnmarshalMsgpack(data); err != nil || f0.Kind() != f.Kind() || f.IsFinite() && !f0.Equal(f) {
+			t.Error("should be equal", f0, f, err)
+		}
+	}
+	f := NewS("1.5")
+	if err := f.UnmarshalMsgpack([]byte{0xc0}); err != nil || f.String() != "1.5" {
+		t.Error("nil should leave the value unchanged", f, err)
+	}
+	if err := f.UnmarshalMsgpack([]byte{0xd7, 2, 0, 0, 0, 0, 0, 0, 0, 0}); err == nil {
+		t.Error("should be an error for the wrong extension type")
+	}
+	if err := f.UnmarshalMsgpack([]byte{0x01}); err == nil {
+		t.Error("should be an error for an integer")
+	}
+}
+
+func TestMsgpackLibrary(t *testing.T) {
+	type order struct {
+		Price Fixed
+		Qty   Fixed
+		Limit *Fixed
+	}
+	o := order{Price: NewS("101.25"), Qty: NaNOverflow}
+	data, err := msgpack.Marshal(&o)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var o0 order
+	if err := msgpack.Unmarshal(data, &o0); err != nil {
+		t.Fatal(err)
+	}
+	if o0.Price.String() != "101.25" || o0.Qty.Kind() != KindOverflow || o0.Limit != nil {
+		t.Error("wrong round trip", o0)
+	}
+
+	// the extension can be decoded generically
+	var ext msgpack.RawMessage
+	_ = msgpack.Unmarshal(data, &ext)
+	price, _ := NewS("101.25").MarshalMsgpack()
+	if !bytes.Contains(ext, price) {
+		t.Error("should contain the extension", ext, price)
+	}
+}
@@ -0,0 +1,99 @@
+package fixed
+
+// bps and percent conversions are exact, using integer scaling of the internal representation. Conversions that
+// can lose precision take a RoundingMode.
+
+// FromBps converts a number of basis points to a Fixed, e.g. FromBps(NewS("12.5"), mode) is 0.00125. NaN and
+// infinities are returned unchanged
+func FromBps(bps Fixed, mode RoundingMode) Fixed {
+	if !bps.IsFinite() {
+		return bps
+	}
+	fp, _ := mulDiv(bps.fp, 1, 10000, mode)
+	return Fixed{fp: fp}
+}
+
+// ToBps converts f to a number of basis points, e.g. 0.00125 is 12.5. NaN and infinities are returned unchanged, and
+// if the result is out of range, NaNOverflow is returned
+func (f Fixed) ToBps() Fixed {
+	return f.scaleUp(10000)
+}
+
+// FromPercent converts a percentage to a Fixed, e.g. FromPercent(NewS("3.25"), mode) is 0.0325. NaN and
+// infinities are returned unchanged
+func FromPercent(pct Fixed, mode RoundingMode) Fixed {
+	if !pct.IsFinite() {
+		return pct
+	}
+	fp, _ := mulDiv(pct.fp, 1, 100, mode)
+	return Fixed{fp: fp}
+}
+
+// ToPercent converts f to a percentage, e.g. 0.0325 is 3.25. NaN and infinities are returned unchanged, and if the
+// result is out of range, NaNOverflow is returned
+func (f Fixed) ToPercent() Fixed {
+	return f.scaleUp(100)
+}
+
+func (f Fixed) scaleUp(n int64) Fixed {
+	if !f.IsFinite() {
+		return f
+	}
+	fp, ok := mulDiv(f.fp, n, 1, RoundDown)
+	if !ok {
+		return NaNOverflow
+	}
+	return Fixed{fp: fp}
+}
+
+// PctChange returns the percentage change from a to b, (b-a)/a*100, rounded using mode. If either operand is NaN,
+// that NaN is returned, and if either is infinite, NaN. If a is zero NaNDivByZero is returned, and if the result is
+// out of range, NaNOverflow
+func PctChange(a, b Fixed, mode RoundingMode) Fixed {
+	if !a.IsFinite() || !b.IsFinite() {
+		return firstNaN(a, b)
+	}
+	if a.fp == 0 {
+		return NaNDivByZero
+	}
+	fp, ok := mulDiv(b.fp-a.fp, 100*scale, a.fp, mode)
+	if !ok {
+		return NaNOverflow
+	}
+	return Fixed{fp: fp}
+}
+
+// ApplyBps returns f adjusted by bps basis points, f*(1+bps/10000), computed exactly and rounded once using mode.
+// NaN and infinities are handled as by Mul and Add, and if the result is out of range, NaNOverflow is returned
+func (f Fixed) ApplyBps(bps Fixed, mode RoundingMode) Fixed {
+	if !f.IsFinite() || !bps.IsFinite() {
+		if bps.IsFinite() {
+			// only the sign of the factor 1+bps/10000 matters
+			return mulSpecial(f, Fixed{fp: 10000*scale + bps.fp})
+		}
+		return f.Add(f.Mul(bps))
+	}
+	fp, ok := mulDiv(f.fp, 10000*scale+bps.fp, 10000*scale, mode)
+	if !ok {
+		return NaNOverflow
+	}
+	return Fixed{fp: fp}
+}
+
+// BpsString formats f as a number of basis points, e.g. 0.00125 is "12.5 bps"
+func (f Fixed) BpsString() string {
+	bps := f.ToBps()
+	if !bps.IsFinite() {
+		return bps.String()
+	}
+	return bps.String() + " bps"
+}
+
+// PercentString formats f as a percentage, e.g. 0.0325 is "3.25%"
+func (f Fixed) PercentString() string {
+	pct := f.ToPercent()
+	if !pct.IsFinite() {
+		return pct.String()
+	}
+	return pct.String() + "%"
+}
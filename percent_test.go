@@ -0,0 +1,124 @@
+package fixed_test
+
+import (
+	"testing"
+
+	. "github.com/robaho/fixed"
+)
+
+func TestBps(t *testing.T) {
+	f := FromBps(NewS("12.5"), RoundHalfUp)
+	if f.String() != "0.00125" {
+		t.Error("should be equal", f, "0.00125")
+	}
+	f = FromBps(NewS("-0.005"), RoundHalfUp)
+	if f.String() != "-0.0000005" {
+		t.Error("should be equal", f, "-0.0000005")
+	}
+	f = FromBps(NewS("0.0005"), RoundHalfEven)
+	if f.String() != "0" {
+		t.Error("should be equal", f, "0")
+	}
+	f = FromBps(NewS("0.0005"), RoundUp)
+	if f.String() != "0.0000001" {
+		t.Error("should be equal", f, "0.0000001")
+	}
+	f = NewS("0.00125").ToBps()
+	if f.String() != "12.5" {
+		t.Error("should be equal", f, "12.5")
+	}
+	f = NewS("99999999").ToBps()
+	if !f.IsNaN() {
+		t.Error("should be NaN", f)
+	}
+	if !FromBps(NaN, RoundHalfUp).IsNaN() || !NaN.ToBps().IsNaN() {
+		t.Error("should be NaN")
+	}
+}
+
+func TestPercent(t *testing.T) {
+	f := FromPercent(NewS("3.25"), RoundHalfUp)
+	if f.String() != "0.0325" {
+		t.Error("should be equal", f, "0.0325")
+	}
+	f = FromPercent(NewS("0.000005"), RoundDown)
+	if f.String() != "0" {
+		t.Error("should be equal", f, "0")
+	}
+	f = NewS("0.0325").ToPercent()
+	if f.String() != "3.25" {
+		t.Error("should be equal", f, "3.25")
+	}
+}
+
+func TestPctChange(t *testing.T) {
+	f := PctChange(NewS("80"), NewS("100"), RoundHalfUp)
+	if f.String() != "25" {
+		t.Error("should be equal", f, "25")
+	}
+	f = PctChange(NewS("3"), NewS("1"), RoundHalfUp)
+	if f.String() != "-66.6666667" {
+		t.Error("should be equal", f, "-66.6666667")
+	}
+	f = PctChange(NewS("3"), NewS("1"), RoundDown)
+	if f.String() != "-66.6666666" {
+		t.Error("should be equal", f, "-66.6666666")
+	}
+	f = PctChange(ZERO, NewS("1"), RoundHalfUp)
+	if !f.IsNaN() {
+		t.Error("should be NaN", f)
+	}
+}
+
+func TestApplyBps(t *testing.T) {
+	f := NewS("100").ApplyBps(NewS("25"), RoundHalfUp)
+	if f.String() != "100.25" {
+		t.Error("should be equal", f, "100.25")
+	}
+	f = NewS("100").ApplyBps(NewS("-25"), RoundHalfUp)
+	if f.String() != "99.75" {
+		t.Error("should be equal", f, "99.75")
+	}
+	f = NewS("0.0001").ApplyBps(NewS("5"), RoundHalfUp)
+	if f.String() != "0.0001001" {
+		t.Error("should be equal", f, "0.0001001")
+	}
+	f = NewS("0.0001").ApplyBps(NewS("5"), RoundHalfEven)
+	if f.String() != "0.0001" {
+		t.Error("should be equal", f, "0.0001")
+	}
+
+	// the result is rounded, not the adjustment, so a negative adjustment or an odd value rounds as MulRound does
+	modes := []RoundingMode{RoundHalfUp, RoundHalfEven, RoundHalfDown, RoundDown, RoundUp, RoundFloor, RoundCeiling}
+	cases := []struct {
+		f, bps string
+		want   []string
+	}{
+		{"0.0000003", "-5000", []string{"0.0000002", "0.0000002", "0.0000001", "0.0000001", "0.0000002", "0.0000001", "0.0000002"}},
+		{"-0.0000003", "-5000", []string{"-0.0000002", "-0.0000002", "-0.0000001", "-0.0000001", "-0.0000002", "-0.0000002", "-0.0000001"}},
+		{"0.0000001", "5000", []string{"0.0000002", "0.0000002", "0.0000001", "0.0000001", "0.0000002", "0.0000001", "0.0000002"}},
+		{"0.0000003", "5000", []string{"0.0000005", "0.0000004", "0.0000004", "0.0000004", "0.0000005", "0.0000004", "0.0000005"}},
+	}
+	for _, c := range cases {
+		for i, mode := range modes {
+			if f := NewS(c.f).ApplyBps(NewS(c.bps), mode); f.String() != c.want[i] {
+				t.Error("should be equal", c.f, c.bps, mode, f, c.want[i])
+			}
+			if f := NewS(c.f).MulRound(NewS("1").Add(FromBps(NewS(c.bps), RoundHalfUp)), 7, mode); f.String() != c.want[i] {
+				t.Error("should be equal", c.f, c.bps, mode, f, c.want[i])
+			}
+		}
+	}
+}
+
+func TestBpsPercentString(t *testing.T) {
+	if s := NewS("0.00125").BpsString(); s != "12.5 bps" {
+		t.Error("should be equal", s, "12.5 bps")
+	}
+	if s := NewS("0.0325").PercentString(); s != "3.25%" {
+		t.Error("should be equal", s, "3.25%")
+	}
+	if s := NaN.PercentString(); s != "NaN" {
+		t.Error("should be equal", s, "NaN")
+	}
+}
@@ -0,0 +1,88 @@
+package fixed
+
+import (
+	"errors"
+	"math"
+	"math/bits"
+)
+
+var ErrOverflow = errors.New("value out of range")
+var ErrPrecision = errors.New("value cannot be represented exactly")
+var ErrNaN = errors.New("value is NaN")
+
+// Raw returns the internal representation of f, i.e. the value scaled by 10^7 as an integer. NaN and infinities are
+// returned as their internal sentinel values, so FromRaw(f.Raw()) always returns f
+func (f Fixed) Raw() int64 {
+	return f.fp
+}
+
+// FromRaw creates a Fixed from its internal representation, as returned by Raw
+func FromRaw(raw int64) Fixed {
+	return Fixed{fp: raw}
+}
+
+// mulPow10 returns v*10^n, and false if it overflows an int64
+func mulPow10(v int64, n int) (int64, bool) {
+	if v == 0 {
+		return 0, true
+	}
+	if n >= len(pow10) {
+		return 0, false
+	}
+	hi, lo := bits.Mul64(uabs(v), uint64(pow10[n]))
+	if hi != 0 || lo > 1<<63-1 {
+		return 0, false
+	}
+	if v < 0 {
+		return -int64(lo), true
+	}
+	return int64(lo), true
+}
+
+// FromScaled creates a Fixed with the value v*10^exp, e.g. FromScaled(12345, -2) is 123.45. An error is returned if
+// the value is out of range, or has more than 7 decimal places
+func FromScaled(v int64, exp int) (Fixed, error) {
+	if exp >= -nPlaces {
+		fp, ok := mulPow10(v, exp+nPlaces)
+		if !ok || fp > maxFP || fp < -maxFP {
+			return NaNOverflow, ErrOverflow
+		}
+		return Fixed{fp: fp}, nil
+	}
+	n := -nPlaces - exp
+	if n >= len(pow10) {
+		if v == 0 {
+			return ZERO, nil
+		}
+		return NaN, ErrPrecision
+	}
+	if v%pow10[n] != 0 {
+		return NaN, ErrPrecision
+	}
+	return Fixed{fp: v / pow10[n]}, nil
+}
+
+// ToScaled returns the value of f as an integer multiple of 10^exp, e.g. 123.456 with exp -2 is 12346 rounding
+// half-up. ErrNaN is returned if f is NaN, and ErrOverflow if it is infinite or the result overflows an int64
+func (f Fixed) ToScaled(exp int, mode RoundingMode) (int64, error) {
+	if err := f.finiteErr(); err != nil {
+		return 0, err
+	}
+	if exp <= -nPlaces {
+		v, ok := mulPow10(f.fp, -nPlaces-exp)
+		if !ok {
+			return 0, ErrOverflow
+		}
+		return v, nil
+	}
+	n := exp + nPlaces
+	if n >= len(pow10) {
+		// |f| is far less than half of 10^exp, so only directed rounding gives a non-zero result
+		if f.fp == 0 || !roundUp(0, 1, math.MaxUint64, f.fp < 0, mode) {
+			return 0, nil
+		}
+		return sign(f.fp), nil
+	}
+	v, _ := mulDiv(f.fp, 1, pow10[n], mode)
+	return v, nil
+}
@@ -0,0 +1,81 @@
+package fixed_test
+
+import (
+	"testing"
+
+	. "github.com/robaho/fixed"
+)
+
+func TestRaw(t *testing.T) {
+	f := NewS("123.456")
+	if f.Raw() != 1234560000 {
+		t.Error("should be equal", f.Raw(), 1234560000)
+	}
+	if !FromRaw(-1234560000).Equal(NewS("-123.456")) {
+		t.Error("should be equal", FromRaw(-1234560000), "-123.456")
+	}
+	if !FromRaw(NaN.Raw()).IsNaN() {
+		t.Error("should be NaN")
+	}
+}
+
+func TestFromScaled(t *testing.T) {
+	testCases := []struct {
+		v   int64
+		exp int
+		out string
+		err error
+	}{
+		{12345, -2, "123.45", nil},
+		{-12345, -2, "-123.45", nil},
+		{12345, 2, "1234500", nil},
+		{123456789, -9, "0.123456789", ErrPrecision},
+		{123456700, -9, "0.1234567", nil},
+		{1, -30, "", ErrPrecision},
+		{0, -30, "0", nil},
+		{1, 11, "", ErrOverflow},
+		{99999999999, 0, "99999999999", nil},
+		{1, 40, "", ErrOverflow},
+	}
+	for _, tc := range testCases {
+		f, err := FromScaled(tc.v, tc.exp)
+		if err != tc.err {
+			t.Error("unexpected error", tc.v, tc.exp, err)
+			continue
+		}
+		if err == nil && f.String() != tc.out {
+			t.Error("should be equal", tc.v, tc.exp, f, tc.out)
+		}
+	}
+}
+
+func TestToScaled(t *testing.T) {
+	testCases := []struct {
+		in   string
+		exp  int
+		mode RoundingMode
+		out  int64
+		err  error
+	}{
+		{"123.456", -2, RoundHalfUp, 12346, nil},
+		{"123.456", -2, RoundDown, 12345, nil},
+		{"-123.455", -2, RoundHalfEven, -12346, nil},
+		{"123.456", -9, RoundHalfUp, 123456000000, nil},
+		{"123.456", 1, RoundHalfUp, 12, nil},
+		{"99999999999", -9, RoundHalfUp, 0, ErrOverflow},
+		{"5", 20, RoundHalfUp, 0, nil},
+		{"5", 20, RoundUp, 1, nil},
+		{"-5", 20, RoundFloor, -1, nil},
+		{"NaN", -2, RoundHalfUp, 0, ErrNaN},
+	}
+	for _, tc := range testCases {
+		v, err := NewS(tc.in).ToScaled(tc.exp, tc.mode)
+		if err != tc.err {
+			t.Error("unexpected error", tc.in, tc.exp, err)
+			continue
+		}
+		if v != tc.out {
+			t.Error("should be equal", tc.in, tc.exp, v, tc.out)
+		}
+	}
+}
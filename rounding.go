@@ -0,0 +1,171 @@
+package fixed
+
+import "math/bits"
+
+// RoundingMode specifies how a result is rounded when it cannot be represented exactly
+type RoundingMode int
+
+const (
+	// RoundHalfUp rounds to the nearest value, with ties away from zero. This is the rounding used by Round
+	RoundHalfUp RoundingMode = iota
+	// RoundHalfEven rounds to the nearest value, with ties to the even neighbour (banker's rounding)
+	RoundHalfEven
+	// RoundHalfDown rounds to the nearest value, with ties toward zero
+	RoundHalfDown
+	// RoundDown rounds toward zero, i.e. truncates
+	RoundDown
+	// RoundUp rounds away from zero
+	RoundUp
+	// RoundFloor rounds toward negative infinity
+	RoundFloor
+	// RoundCeiling rounds toward positive infinity
+	RoundCeiling
+)
+
+// maxFP is the largest magnitude of the internal representation, see MAX
+const maxFP = int64(999999999999999999)
+
+var pow10 = [...]int64{
+	1,
+	10,
+	100,
+	1000,
+	10000,
+	100000,
+	1000000,
+	10000000,
+	100000000,
+	1000000000,
+	10000000000,
+	100000000000,
+	1000000000000,
+	10000000000000,
+	100000000000000,
+	1000000000000000,
+	10000000000000000,
+	100000000000000000,
+	1000000000000000000,
+}
+
+func uabs(i int64) uint64 {
+	if i < 0 {
+		return uint64(-i)
+	}
+	return uint64(i)
+}
+
+// roundUp reports whether the truncated quotient q, having remainder r from a division by d, must be
+// incremented in magnitude to honor the rounding mode. neg is the sign of the exact result.
+func roundUp(q, r, d uint64, neg bool, mode RoundingMode) bool {
+	if r == 0 {
+		return false
+	}
+	switch mode {
+	case RoundDown:
+		return false
+	case RoundUp:
+		return true
+	case RoundFloor:
+		return neg
+	case RoundCeiling:
+		return !neg
+	}
+	// compare r with d/2 without overflowing
+	other := d - r
+	if r > other {
+		return true
+	}
+	if r < other {
+		return false
+	}
+	switch mode {
+	case RoundHalfEven:
+		return q&1 == 1
+	case RoundHalfDown:
+		return false
+	}
+	return true
+}
+
+// mulDiv returns a*b/c, computed exactly in 128 bits and then rounded according to mode. ok is false if
+// c is zero or the result is larger than maxFP
+func mulDiv(a, b, c int64, mode RoundingMode) (result int64, ok bool) {
+	if c == 0 {
+		return 0, false
+	}
+	neg := (a < 0) != (b < 0) != (c < 0)
+	hi, lo := bits.Mul64(uabs(a), uabs(b))
+	d := uabs(c)
+	if hi >= d {
+		return 0, false
+	}
+	q, r := bits.Div64(hi, lo, d)
+	if roundUp(q, r, d, neg, mode) {
+		q++
+	}
+	if q > uint64(maxFP) {
+		return 0, false
+	}
+	if neg {
+		return -int64(q), true
+	}
+	return int64(q), true
+}
+
+// scaleBy returns fp * 10^(nPlaces-n), or NaNOverflow if the result is out of range
+func scaleBy(fp int64, n int) Fixed {
+	fp, ok := mulDiv(fp, pow10[nPlaces-n], 1, RoundDown)
+	if !ok {
+		return NaNOverflow
+	}
+	return Fixed{fp: fp}
+}
+
+func clampPlaces(n int) int {
+	if n < 0 {
+		return 0
+	}
+	if n > nPlaces {
+		return nPlaces
+	}
+	return n
+}
+
+// RoundTo rounds f to n decimal places using the specified rounding mode. n is limited to the range [0,7]. NaN and infinities are returned unchanged
+func (f Fixed) RoundTo(n int, mode RoundingMode) Fixed {
+	if !f.IsFinite() {
+		return f
+	}
+	n = clampPlaces(n)
+	fp, _ := mulDiv(f.fp, 1, pow10[nPlaces-n], mode)
+	return scaleBy(fp, n)
+}
+
+// MulRound multiplies f by f0, computing the exact product and rounding it once to n decimal places using the
+// specified rounding mode. n is limited to the range [0,7]. NaN and infinities are handled as by Mul. If the result is out of range, NaNOverflow is returned
+func (f Fixed) MulRound(f0 Fixed, n int, mode RoundingMode) Fixed {
+	if !f.IsFinite() || !f0.IsFinite() {
+		return mulSpecial(f, f0)
+	}
+	n = clampPlaces(n)
+	fp, ok := mulDiv(f.fp, f0.fp, scale*pow10[nPlaces-n], mode)
+	if !ok {
+		return NaNOverflow
+	}
+	return scaleBy(fp, n)
+}
+
+// DivRound divides f by f0 exactly, rounding the quotient once to n decimal places using the specified rounding
+// mode. n is limited to the range [0,7]. Unlike Div, no floating point is used. NaN, infinities and division by zero
+// are handled as by Div. If the result is out of range, NaNOverflow is returned
+func (f Fixed) DivRound(f0 Fixed, n int, mode RoundingMode) Fixed {
+	if !f.IsFinite() || !f0.IsFinite() || f0.fp == 0 {
+		return divSpecial(f, f0)
+	}
+	n = clampPlaces(n)
+	fp, ok := mulDiv(f.fp, pow10[n], f0.fp, mode)
+	if !ok {
+		return NaNOverflow
+	}
+	return scaleBy(fp, n)
+}
@@ -0,0 +1,90 @@
+package fixed_test
+
+import (
+	"testing"
+
+	. "github.com/robaho/fixed"
+)
+
+func TestRoundTo(t *testing.T) {
+	testCases := []struct {
+		in   string
+		n    int
+		mode RoundingMode
+		out  string
+	}{
+		{"1.125", 2, RoundHalfUp, "1.13"},
+		{"-1.125", 2, RoundHalfUp, "-1.13"},
+		{"1.125", 2, RoundHalfEven, "1.12"},
+		{"1.135", 2, RoundHalfEven, "1.14"},
+		{"-1.125", 2, RoundHalfEven, "-1.12"},
+		{"1.125", 2, RoundHalfDown, "1.12"},
+		{"1.1251", 2, RoundHalfDown, "1.13"},
+		{"1.129", 2, RoundDown, "1.12"},
+		{"-1.129", 2, RoundDown, "-1.12"},
+		{"1.121", 2, RoundUp, "1.13"},
+		{"-1.121", 2, RoundUp, "-1.13"},
+		{"-1.121", 2, RoundFloor, "-1.13"},
+		{"1.129", 2, RoundFloor, "1.12"},
+		{"-1.129", 2, RoundCeiling, "-1.12"},
+		{"1.121", 2, RoundCeiling, "1.13"},
+		{"2.5", 0, RoundHalfEven, "2"},
+		{"1.1234567", 7, RoundUp, "1.1234567"},
+		{"1.1234567", 9, RoundUp, "1.1234567"},
+	}
+	for _, tc := range testCases {
+		f := NewS(tc.in).RoundTo(tc.n, tc.mode)
+		if f.String() != tc.out {
+			t.Error("should be equal", tc.in, tc.n, tc.mode, f, tc.out)
+		}
+	}
+	if !NaN.RoundTo(2, RoundHalfUp).IsNaN() {
+		t.Error("should be NaN")
+	}
+}
+
+func TestMulRound(t *testing.T) {
+	f := NewS("1.0050001").MulRound(NewS("1"), 2, RoundHalfEven)
+	if f.String() != "1.01" {
+		t.Error("should be equal", f, "1.01")
+	}
+	f = NewS("0.0000005").MulRound(NewS("0.5"), 7, RoundHalfUp)
+	if f.String() != "0.0000003" {
+		t.Error("should be equal", f, "0.0000003")
+	}
+	f = NewS("0.0000005").MulRound(NewS("0.5"), 7, RoundHalfEven)
+	if f.String() != "0.0000002" {
+		t.Error("should be equal", f, "0.0000002")
+	}
+	f = NewS("-123.456").MulRound(NewS("1000"), 7, RoundHalfUp)
+	if f.String() != "-123456" {
+		t.Error("should be equal", f, "-123456")
+	}
+	f = NewS("99999999").MulRound(NewS("99999999"), 7, RoundHalfUp)
+	if !f.IsNaN() {
+		t.Error("should be NaN", f)
+	}
+}
+
+func TestDivRound(t *testing.T) {
+	f := NewS("2").DivRound(NewS("3"), 7, RoundHalfUp)
+	if f.String() != "0.6666667" {
+		t.Error("should be equal", f, "0.6666667")
+	}
+	f = NewS("2").DivRound(NewS("3"), 7, RoundDown)
+	if f.String() != "0.6666666" {
+		t.Error("should be equal", f, "0.6666666")
+	}
+	f = NewS("-2").DivRound(NewS("3"), 2, RoundFloor)
+	if f.String() != "-0.67" {
+		t.Error("should be equal", f, "-0.67")
+	}
+	f = NewS("99999999999").DivRound(NewS("0.1"), 7, RoundHalfUp)
+	if !f.IsNaN() {
+		t.Error("should be NaN", f)
+	}
+	f = NewS("1").DivRound(ZERO, 7, RoundHalfUp)
+	if !f.IsNaN() {
+		t.Error("should be NaN", f)
+	}
+}
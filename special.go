@@ -0,0 +1,174 @@
+package fixed
+
+// The raw values above the largest finite value are reserved for infinities and NaNs. A NaN may carry a kind to
+// record why it was produced, which is propagated through arithmetic, so that a missing value can be distinguished
+// from a division by zero or an overflow. All NaN kinds are NaN, and format as "NaN".
+
+const (
+	nanDivByZero = nan - 1
+	nanOverflow  = nan - 2
+	// the values between posInf and nan are reserved for other kinds of NaN
+	posInf = nan - 16
+	negInf = -posInf
+)
+
+var PosInf = Fixed{fp: posInf}
+var NegInf = Fixed{fp: negInf}
+
+// NaNDivByZero is the NaN produced by a division by zero
+var NaNDivByZero = Fixed{fp: nanDivByZero}
+
+// NaNOverflow is the NaN produced when a result is out of range
+var NaNOverflow = Fixed{fp: nanOverflow}
+
+// Kind classifies a Fixed as finite, infinite, or one of the kinds of NaN
+type Kind int
+
+const (
+	KindFinite Kind = iota
+	KindNaN
+	KindPosInf
+	KindNegInf
+	KindDivByZero
+	KindOverflow
+)
+
+func (k Kind) String() string {
+	switch k {
+	case KindFinite:
+		return "Finite"
+	case KindPosInf:
+		return "+Inf"
+	case KindNegInf:
+		return "-Inf"
+	case KindDivByZero:
+		return "NaN(DivByZero)"
+	case KindOverflow:
+		return "NaN(Overflow)"
+	}
+	return "NaN"
+}
+
+// Kind returns the kind of f
+func (f Fixed) Kind() Kind {
+	switch f.fp {
+	case posInf:
+		return KindPosInf
+	case negInf:
+		return KindNegInf
+	case nanDivByZero:
+		return KindDivByZero
+	case nanOverflow:
+		return KindOverflow
+	}
+	if f.IsNaN() {
+		return KindNaN
+	}
+	return KindFinite
+}
+
+// IsFinite returns true if f is neither NaN nor infinite
+func (f Fixed) IsFinite() bool {
+	return f.fp < posInf && f.fp > negInf
+}
+
+// IsInf reports whether f is an infinity, according to sign. If sign > 0, IsInf reports whether f is positive
+// infinity. If sign < 0, IsInf reports whether f is negative infinity. If sign == 0, IsInf reports whether f is either
+// infinity
+func (f Fixed) IsInf(sign int) bool {
+	return sign >= 0 && f.fp == posInf || sign <= 0 && f.fp == negInf
+}
+
+// parseSpecial returns the Fixed for the string form of a non-finite kind
+func parseSpecial(s string) (Fixed, bool) {
+	switch s {
+	case "NaN":
+		return NaN, true
+	case "+Inf", "Inf":
+		return PosInf, true
+	case "-Inf":
+		return NegInf, true
+	case "NaN(DivByZero)":
+		return NaNDivByZero, true
+	case "NaN(Overflow)":
+		return NaNOverflow, true
+	}
+	return NaN, false
+}
+
+// firstNaN returns the first of the operands that is NaN, preserving its kind, or NaN if neither is
+func firstNaN(f, f0 Fixed) Fixed {
+	if f.IsNaN() {
+		return f
+	}
+	if f0.IsNaN() {
+		return f0
+	}
+	return NaN
+}
+
+// infOf returns the infinity with the sign of sign
+func infOf(sign int64) Fixed {
+	if sign < 0 {
+		return NegInf
+	}
+	return PosInf
+}
+
+// neg returns -f, preserving the kind of a NaN
+func (f Fixed) neg() Fixed {
+	if f.IsNaN() {
+		return f
+	}
+	return Fixed{fp: -f.fp}
+}
+
+// addSpecial returns f+f0 when either is not finite
+func addSpecial(f, f0 Fixed) Fixed {
+	switch {
+	case f.IsNaN() || f0.IsNaN():
+		return firstNaN(f, f0)
+	case f.IsInf(0) && f0.IsInf(0) && f.fp != f0.fp:
+		return NaN
+	case f.IsInf(0):
+		return f
+	}
+	return f0
+}
+
+// mulSpecial returns f*f0 when either is not finite
+func mulSpecial(f, f0 Fixed) Fixed {
+	if f.IsNaN() || f0.IsNaN() {
+		return firstNaN(f, f0)
+	}
+	if f.fp == 0 || f0.fp == 0 {
+		return NaN
+	}
+	return infOf(sign(f.fp) * sign(f0.fp))
+}
+
+// divSpecial returns f/f0 when either is not finite, or f0 is zero
+func divSpecial(f, f0 Fixed) Fixed {
+	switch {
+	case f.IsNaN() || f0.IsNaN():
+		return firstNaN(f, f0)
+	case f0.fp == 0:
+		return NaNDivByZero
+	case f.IsInf(0) && f0.IsInf(0):
+		return NaN
+	case f0.IsInf(0):
+		return ZERO
+	}
+	return infOf(sign(f.fp) * sign(f0.fp))
+}
+
+// finiteErr returns ErrNaN if f is NaN, ErrOverflow if it is infinite, and nil otherwise
+func (f Fixed) finiteErr() error {
+	if f.IsNaN() {
+		return ErrNaN
+	}
+	if f.IsInf(0) {
+		return ErrOverflow
+	}
+	return nil
+}
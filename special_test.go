@@ -0,0 +1,168 @@
+package fixed_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"testing"
+
+	. "github.com/robaho/fixed"
+)
+
+func TestKind(t *testing.T) {
+	testCases := []struct {
+		f    Fixed
+		kind Kind
+		s    string
+	}{
+		{NewS("1.5"), KindFinite, "1.5"},
+		{NaN, KindNaN, "NaN"},
+		{PosInf, KindPosInf, "+Inf"},
+		{NegInf, KindNegInf, "-Inf"},
+		{NaNDivByZero, KindDivByZero, "NaN"},
+		{NaNOverflow, KindOverflow, "NaN"},
+	}
+	for _, tc := range testCases {
+		if tc.f.Kind() != tc.kind || tc.f.String() != tc.s {
+			t.Error("wrong kind", tc.f, tc.f.Kind(), tc.kind)
+		}
+		if tc.f.IsFinite() != (tc.kind == KindFinite) {
+			t.Error("wrong IsFinite", tc.f)
+		}
+		if tc.f.IsNaN() != (tc.kind != KindFinite && !tc.f.IsInf(0)) {
+			t.Error("wrong IsNaN", tc.f)
+		}
+	}
+	if !PosInf.IsInf(1) || PosInf.IsInf(-1) || !NegInf.IsInf(-1) || NegInf.IsInf(1) || NaN.IsInf(0) {
+		t.Error("wrong IsInf")
+	}
+	if !FromRaw(math.MinInt64).IsNaN() {
+		t.Error("invalid raw values should be NaN")
+	}
+}
+
+func TestSpecialResults(t *testing.T) {
+	one := NewS("1")
+	testCases := []struct {
+		name string
+		f    Fixed
+		kind Kind
+	}{
+		{"div by zero", one.Div(ZERO), KindDivByZero},
+		{"zero div zero", ZERO.Div(ZERO), KindDivByZero},
+		{"div round by zero", one.DivRound(ZERO, 2, RoundHalfUp), KindDivByZero},
+		{"NewF overflow", NewF(1e12), KindOverflow},
+		{"NewF infinity", NewF(math.Inf(-1)), KindNegInf},
+		{"MulRound overflow", NewS("99999999").MulRound(NewS("99999999"), 2, RoundHalfUp), KindOverflow},
+		{"PctChange from zero", PctChange(ZERO, one, RoundHalfUp), KindDivByZero},
+		{"FromInt overflow", FromInt(1 << 40), KindOverflow},
+		{"inf plus finite", PosInf.Add(one), KindPosInf},
+		{"inf minus inf", PosInf.Sub(PosInf), KindNaN},
+		{"inf plus -inf", PosInf.Add(NegInf), KindNaN},
+		{"finite minus inf", one.Sub(PosInf), KindNegInf},
+		{"inf times negative", PosInf.Mul(NewS("-2")), KindNegInf},
+		{"inf times zero", NegInf.Mul(ZERO), KindNaN},
+		{"inf div finite", NegInf.Div(NewS("-2")), KindPosInf},
+		{"finite div inf", one.Div(PosInf), KindFinite},
+		{"inf div inf", PosInf.Div(NegInf), KindNaN},
+		{"abs", NegInf.Abs(), KindPosInf},
+		{"round", NegInf.RoundTo(2, RoundHalfUp), KindNegInf},
+		{"propagate add", NaNDivByZero.Add(one), KindDivByZero},
+		{"propagate sub", one.Sub(NaNOverflow), KindOverflow},
+		{"propagate mul", PosInf.Mul(NaNDivByZero), KindDivByZero},
+		{"propagate div", NaNOverflow.Div(ZERO), KindOverflow},
+		{"propagate first", NaNOverflow.Add(NaNDivByZero), KindOverflow},
+		{"propagate abs", NaNDivByZero.Abs(), KindDivByZero},
+		{"propagate sum", Sum([]Fixed{one, NaNDivByZero, NaN}), KindDivByZero},
+		{"sum of infinities", Sum([]Fixed{one, PosInf, one}), KindPosInf},
+		{"max of infinity", Max([]Fixed{one, PosInf, NegInf}), KindPosInf},
+		{"weights sum to zero", WeightedMean(fixeds("1", "2"), fixeds("1", "-1")), KindDivByZero},
+		{"sum slice", SumSlice([]Fixed{one, NegInf}), KindNegInf},
+		{"dot product", DotProduct([]Fixed{one, PosInf}, []Fixed{one, NewS("-1")}), KindNegInf},
+	}
+	for _, tc := range testCases {
+		if tc.f.Kind() != tc.kind {
+			t.Error(tc.name, "wrong kind", tc.f.Kind(), tc.kind)
+		}
+	}
+	if f := one.Div(PosInf); !f.Equal(ZERO) {
+		t.Error("should be zero", f)
+	}
+}
+
+func TestSpecialCmp(t *testing.T) {
+	ordered := []Fixed{NegInf, NewS("-99999999999"), ZERO, NewS("99999999999"), PosInf, NaN}
+	for i := range ordered {
+		for j := range ordered {
+			want := 0
+			if i < j {
+				want = -1
+			} else if i > j {
+				want = 1
+			}
+			if c := ordered[i].Cmp(ordered[j]); c != want {
+				t.Error("wrong Cmp", ordered[i], ordered[j], c, want)
+			}
+		}
+	}
+	if !PosInf.Equal(PosInf) || PosInf.Equal(NegInf) || NaNOverflow.Equal(NaNOverflow) {
+		t.Error("wrong Equal")
+	}
+	if NaNDivByZero.Cmp(NaN) != 0 {
+		t.Error("NaN kinds should compare equal")
+	}
+	if PosInf.Sign() != 1 || NegInf.Sign() != -1 || NaNOverflow.Sign() != 0 {
+		t.Error("wrong Sign")
+	}
+	if !math.IsInf(NegInf.Float(), -1) || !math.IsNaN(NaNDivByZero.Float()) {
+		t.Error("wrong Float")
+	}
+}
+
+func TestSpecialParse(t *testing.T) {
+	for _, s := range []string{"NaN", "+Inf", "-Inf", "NaN(DivByZero)", "NaN(Overflow)"} {
+		f, err := NewSErr(s)
+		if err != nil || f.Kind().String() != s {
+			t.Error("should parse", s, f.Kind(), err)
+		}
+	}
+	if f := NewS("Inf"); !f.IsInf(1) {
+		t.Error("should be +Inf", f)
+	}
+}
+
+func TestSpecialEncoding(t *testing.T) {
+	values := []Fixed{NaN, PosInf, NegInf, NaNDivByZero, NaNOverflow}
+
+	b, _ := json.Marshal(values)
+	if string(b) != `["NaN","+Inf","-Inf","NaN(DivByZero)","NaN(Overflow)"]` {
+		t.Error("wrong json", string(b))
+	}
+	var decoded []Fixed
+	if err := json.Unmarshal(b, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	for i, f := range decoded {
+		if f.Kind() != values[i].Kind() {
+			t.Error("json should round trip", values[i].Kind(), f.Kind())
+		}
+	}
+	var f Fixed
+	if err := json.Unmarshal([]byte(`"Infinity"`), &f); err == nil {
+		t.Error("should not decode", f)
+	}
+
+	for _, v := range values {
+		b, _ := v.MarshalBinary()
+		var f Fixed
+		if err := f.UnmarshalBinary(b); err != nil || f.Kind() != v.Kind() {
+			t.Error("binary should round trip", v.Kind(), f.Kind(), err)
+		}
+		var buf bytes.Buffer
+		_ = v.WriteTo(&buf)
+		f, err := ReadFrom(&buf)
+		if err != nil || f.Kind() != v.Kind() {
+			t.Error("WriteTo should round trip", v.Kind(), f.Kind(), err)
+		}
+	}
+}
@@ -0,0 +1,401 @@
+package fixed
+
+import (
+	"math"
+	"math/bits"
+)
+
+// The streaming accumulators below take Fixed inputs and return Fixed outputs, using integer arithmetic only, so
+// results are deterministic. None of them allocate after construction. A NaN input, or an infinite one for the
+// averages and variance, makes the result NaN, until it is reset or, for the windowed accumulators, the input leaves
+// the window. The rolling minimum and maximum order infinities beyond all finite values. Results are rounded half-up. The
+// accumulators are not safe for concurrent use.
+
+// RunningMean accumulates the arithmetic mean of a stream of values. The zero value is ready to use
+type RunningMean struct {
+	sum int128
+	n   int64
+	nan bool
+}
+
+// Add adds a value to the mean
+func (m *RunningMean) Add(f Fixed) {
+	if !f.IsFinite() {
+		m.nan = true
+		return
+	}
+	var ok bool
+	if m.sum, ok = m.sum.add(int128From(f.fp)); !ok {
+		m.nan = true
+	}
+	m.n++
+}
+
+// Count returns the number of values added
+func (m *RunningMean) Count() int64 {
+	return m.n
+}
+
+// Mean returns the mean of the values added, or NaN if there are none
+func (m *RunningMean) Mean() Fixed {
+	if m.nan || m.n == 0 {
+		return NaN
+	}
+	fp, ok := m.sum.divRound(m.n, RoundHalfUp)
+	if !ok {
+		return NaN
+	}
+	return Fixed{fp: fp}
+}
+
+// Reset clears the accumulator
+func (m *RunningMean) Reset() {
+	*m = RunningMean{}
+}
+
+// RunningVariance accumulates the mean, variance and standard deviation of a stream of values. The sum is held
+// exactly in 128 bits and the sum of squares exactly in 256 bits, so each result is exact before its single final
+// rounding. The zero value is ready to use
+type RunningVariance struct {
+	n     int64
+	sum   int128
+	sumSq uint256
+	nan   bool
+}
+
+// Add adds a value to the accumulator
+func (v *RunningVariance) Add(f Fixed) {
+	if !f.IsFinite() {
+		v.nan = true
+		return
+	}
+	v.n++
+	var ok bool
+	if v.sum, ok = v.sum.add(int128From(f.fp)); !ok {
+		v.nan = true
+	}
+	v.sumSq = v.sumSq.add(uint256From(bits.Mul64(uabs(f.fp), uabs(f.fp))))
+}
+
+// Count returns the number of values added
+func (v *RunningVariance) Count() int64 {
+	return v.n
+}
+
+// Mean returns the mean of the values added, or NaN if there are none
+func (v *RunningVariance) Mean() Fixed {
+	if v.nan || v.n == 0 {
+		return NaN
+	}
+	fp, ok := v.sum.divRound(v.n, RoundHalfUp)
+	if !ok {
+		return NaN
+	}
+	return Fixed{fp: fp}
+}
+
+// Variance returns the sample variance of the values added, or NaN if there are fewer than 2
+func (v *RunningVariance) Variance() Fixed {
+	return v.variance(v.n - 1)
+}
+
+// PopulationVariance returns the population variance of the values added, or NaN if there are none
+func (v *RunningVariance) PopulationVariance() Fixed {
+	return v.variance(v.n)
+}
+
+// StdDev returns the sample standard deviation of the values added, or NaN if there are fewer than 2
+func (v *RunningVariance) StdDev() Fixed {
+	return v.stddev(v.n - 1)
+}
+
+// PopulationStdDev returns the population standard deviation of the values added, or NaN if there are none
+func (v *RunningVariance) PopulationStdDev() Fixed {
+	return v.stddev(v.n)
+}
+
+// Reset clears the accumulator
+func (v *RunningVariance) Reset() {
+	*v = RunningVariance{}
+}
+
+// spread returns n*sumSq - sum*sum, which is n times the sum of squared differences from the mean, in units of 1e-14
+func (v *RunningVariance) spread() uint256 {
+	hi, lo := v.sum.abs()
+	return v.sumSq.mul(uint64(v.n)).sub(mul256(hi, lo, hi, lo))
+}
+
+func (v *RunningVariance) variance(d int64) Fixed {
+	if v.nan || d <= 0 {
+		return NaN
+	}
+	// the variance is spread/(n*d*scale), rounded half-up as (2*spread + n*d*scale) / (2*n*d*scale)
+	q := v.spread().mul(2).add(uint256From(0, uint64(v.n)).mul(uint64(d)).mul(uint64(scale)))
+	q, _ = q.div(2)
+	q, _ = q.div(uint64(v.n))
+	q, _ = q.div(uint64(d))
+	q, _ = q.div(uint64(scale))
+	hi, lo, ok := q.uint128()
+	if !ok || hi != 0 || lo > uint64(maxFP) {
+		return NaN
+	}
+	return Fixed{fp: int64(lo)}
+}
+
+func (v *RunningVariance) stddev(d int64) Fixed {
+	if v.nan || d <= 0 {
+		return NaN
+	}
+	// the standard deviation is sqrt(spread/(n*d)) in units of 1e-7, and rounded half-up it is (r+1)/2 where
+	// r = isqrt(4*spread/(n*d)), since the square root of the integer part of a value has the same integer part as
+	// the square root of the value
+	q, _ := v.spread().mul(4).div(uint64(v.n))
+	q, _ = q.div(uint64(d))
+	hi, lo, ok := q.uint128()
+	if !ok {
+		return NaN
+	}
+	fp := (isqrt128(hi, lo) + 1) / 2
+	if fp > uint64(maxFP) {
+		return NaN
+	}
+	return Fixed{fp: int64(fp)}
+}
+
+// isqrt128 returns the square root of the unsigned 128 bit value hi:lo, rounded down
+func isqrt128(hi, lo uint64) uint64 {
+	// estimate with floating point, which is deterministic, and correct the estimate exactly
+	x := uint64(math.Sqrt(float64(hi)*(1<<64) + float64(lo)))
+	// x*x > n
+	for {
+		shi, slo := bits.Mul64(x, x)
+		if shi > hi || (shi == hi && slo > lo) {
+			x--
+			continue
+		}
+		break
+	}
+	// (x+1)*(x+1) <= n
+	for {
+		shi, slo := bits.Mul64(x+1, x+1)
+		if shi < hi || (shi == hi && slo <= lo) {
+			x++
+			continue
+		}
+		break
+	}
+	return x
+}
+
+// EMA is an exponential moving average, value = value + alpha*(x-value). The first value added initializes the
+// average
+type EMA struct {
+	alpha Fixed
+	value Fixed
+	n     int64
+}
+
+// NewEMA creates an EMA with the smoothing factor alpha, which should be in the range (0,1]
+func NewEMA(alpha Fixed) *EMA {
+	return &EMA{alpha: alpha, value: NaN}
+}
+
+// Add adds a value to the average
+func (e *EMA) Add(f Fixed) {
+	if e.n == 0 {
+		e.value = f
+	} else {
+		e.value = e.value.Add(f.Sub(e.value).MulRound(e.alpha, nPlaces, RoundHalfUp))
+	}
+	e.n++
+}
+
+// Value returns the current average, or NaN if no values have been added
+func (e *EMA) Value() Fixed {
+	return e.value
+}
+
+// Reset clears the average
+func (e *EMA) Reset() {
+	e.value = NaN
+	e.n = 0
+}
+
+// window is a ring buffer of the most recent values
+type window struct {
+	buf  []Fixed
+	next int
+	n    int
+	nans int
+}
+
+// push adds f to the window, returning the value evicted, if any
+func (w *window) push(f Fixed) (evicted Fixed, ok bool) {
+	if w.n == len(w.buf) {
+		evicted, ok = w.buf[w.next], true
+		if !evicted.IsFinite() {
+			w.nans--
+		}
+	} else {
+		w.n++
+	}
+	if !f.IsFinite() {
+		w.nans++
+	}
+	w.buf[w.next] = f
+	w.next++
+	if w.next == len(w.buf) {
+		w.next = 0
+	}
+	return
+}
+
+// SMA is a simple moving average of the most recent values
+type SMA struct {
+	w   window
+	sum int128
+}
+
+// NewSMA creates an SMA over a window of n values
+func NewSMA(n int) *SMA {
+	return &SMA{w: window{buf: make([]Fixed, max(n, 1))}}
+}
+
+// Add adds a value to the window, evicting the oldest if the window is full
+func (s *SMA) Add(f Fixed) {
+	evicted, ok := s.w.push(f)
+	if ok && evicted.IsFinite() {
+		s.sum, _ = s.sum.add(int128From(-evicted.fp))
+	}
+	if f.IsFinite() {
+		s.sum, _ = s.sum.add(int128From(f.fp))
+	}
+}
+
+// Full returns true if the window is full
+func (s *SMA) Full() bool {
+	return s.w.n == len(s.w.buf)
+}
+
+// Value returns the average of the values in the window, or NaN if the window is empty or contains a NaN or an
+// infinity
+func (s *SMA) Value() Fixed {
+	if s.w.n == 0 || s.w.nans > 0 {
+		return NaN
+	}
+	fp, ok := s.sum.divRound(int64(s.w.n), RoundHalfUp)
+	if !ok {
+		return NaN
+	}
+	return Fixed{fp: fp}
+}
+
+// Reset clears the window
+func (s *SMA) Reset() {
+	*s = SMA{w: window{buf: s.w.buf}}
+}
+
+// rolling tracks the minimum or maximum of the most recent values using a monotonic deque
+type rolling struct {
+	// deque of sequence numbers and values, in a ring buffer
+	seqs   []int64
+	values []int64
+	head   int
+	len    int
+	seq    int64
+	// sequence number of the most recent NaN, or -1
+	nan    int64
+	size   int64
+	better func(f, f0 int64) bool
+}
+
+func newRolling(n int, better func(f, f0 int64) bool) rolling {
+	n = max(n, 1)
+	return rolling{seqs: make([]int64, n), values: make([]int64, n), nan: -1, size: int64(n), better: better}
+}
+
+func (r *rolling) index(i int) int {
+	return (r.head + i) % len(r.seqs)
+}
+
+func (r *rolling) add(f Fixed) {
+	seq := r.seq
+	r.seq++
+	// drop the front if it has left the window
+	if r.len > 0 && r.seqs[r.head] <= seq-r.size {
+		r.head = r.index(1)
+		r.len--
+	}
+	if f.IsNaN() {
+		r.nan = seq
+		return
+	}
+	// drop values from the back that can no longer be the extreme
+	for r.len > 0 && !r.better(r.values[r.index(r.len-1)], f.fp) {
+		r.len--
+	}
+	i := r.index(r.len)
+	r.seqs[i], r.values[i] = seq, f.fp
+	r.len++
+}
+
+func (r *rolling) value() Fixed {
+	if r.len == 0 || (r.nan >= 0 && r.nan > r.seq-1-r.size) {
+		return NaN
+	}
+	return Fixed{fp: r.values[r.head]}
+}
+
+func (r *rolling) reset() {
+	r.head, r.len, r.seq, r.nan = 0, 0, 0, -1
+}
+
+// RollingMin tracks the minimum of the most recent values
+type RollingMin struct {
+	r rolling
+}
+
+// NewRollingMin creates a RollingMin over a window of n values
+func NewRollingMin(n int) *RollingMin {
+	return &RollingMin{r: newRolling(n, func(f, f0 int64) bool { return f < f0 })}
+}
+
+// Add adds a value to the window, evicting the oldest if the window is full
+func (m *RollingMin) Add(f Fixed) {
+	m.r.add(f)
+}
+
+// Value returns the minimum of the values in the window, or NaN if the window is empty or contains a NaN
+func (m *RollingMin) Value() Fixed {
+	return m.r.value()
+}
+
+// Reset clears the window
+func (m *RollingMin) Reset() {
+	m.r.reset()
+}
+
+// RollingMax tracks the maximum of the most recent values
+type RollingMax struct {
+	r rolling
+}
+
+// NewRollingMax creates a RollingMax over a window of n values
+func NewRollingMax(n int) *RollingMax {
+	return &RollingMax{r: newRolling(n, func(f, f0 int64) bool { return f > f0 })}
+}
+
+// Add adds a value to the window, evicting the oldest if the window is full
+func (m *RollingMax) Add(f Fixed) {
+	m.r.add(f)
+}
+
+// Value returns the maximum of the values in the window, or NaN if the window is empty or contains a NaN
+func (m *RollingMax) Value() Fixed {
+	return m.r.value()
+}
+
+// Reset clears the window
+func (m *RollingMax) Reset() {
+	m.r.reset()
+}
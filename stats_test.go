@@ -0,0 +1,212 @@
+package fixed_test
+
+import (
+	"math/big"
+	"math/rand"
+	"testing"
+
+	. "github.com/robaho/fixed"
+)
+
+func TestRunningMean(t *testing.T) {
+	var m RunningMean
+	if !m.Mean().IsNaN() {
+		t.Error("should be NaN", m.Mean())
+	}
+	for _, f := range fixeds("1", "2", "4") {
+		m.Add(f)
+	}
+	if m.Count() != 3 || m.Mean().String() != "2.3333333" {
+		t.Error("should be equal", m.Count(), m.Mean(), "2.3333333")
+	}
+	m.Add(NaN)
+	if !m.Mean().IsNaN() {
+		t.Error("should be NaN", m.Mean())
+	}
+	m.Reset()
+	m.Add(NewS("5"))
+	if m.Mean().String() != "5" {
+		t.Error("should be equal", m.Mean(), "5")
+	}
+}
+
+func TestRunningVariance(t *testing.T) {
+	var v RunningVariance
+	for _, f := range fixeds("10.5", "11.25", "9.75", "12", "10") {
+		v.Add(f)
+	}
+	if v.Mean().String() != "10.7" {
+		t.Error("should be equal", v.Mean(), "10.7")
+	}
+	if v.Variance().String() != "0.85625" {
+		t.Error("should be equal", v.Variance(), "0.85625")
+	}
+	if v.PopulationVariance().String() != "0.685" {
+		t.Error("should be equal", v.PopulationVariance(), "0.685")
+	}
+	if v.StdDev().String() != "0.9253378" {
+		t.Error("should be equal", v.StdDev(), "0.9253378")
+	}
+	if v.PopulationStdDev().String() != "0.8276473" {
+		t.Error("should be equal", v.PopulationStdDev(), "0.8276473")
+	}
+
+	v.Reset()
+	v.Add(NewS("99999999999"))
+	if !v.Variance().IsNaN() || v.PopulationVariance().String() != "0" {
+		t.Error("wrong variance of a single value", v.Variance(), v.PopulationVariance())
+	}
+	v.Add(NewS("-99999999999"))
+	if v.PopulationStdDev().String() != "99999999999" {
+		t.Error("should be equal", v.PopulationStdDev(), "99999999999")
+	}
+	v.Add(NaN)
+	if !v.StdDev().IsNaN() {
+		t.Error("should be NaN", v.StdDev())
+	}
+}
+
+func TestRunningVarianceExact(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	// half-up rounding of x to 7 places, for a non-negative x
+	round := func(x *big.Rat) string {
+		x = new(big.Rat).Mul(x, big.NewRat(1e7, 1))
+		q, m := new(big.Int).QuoRem(x.Num(), x.Denom(), new(big.Int))
+		if m.Lsh(m, 1).Cmp(x.Denom()) >= 0 {
+			q.Add(q, big.NewInt(1))
+		}
+		return FromRaw(q.Int64()).String()
+	}
+	for trial := 0; trial < 100; trial++ {
+		var v RunningVariance
+		values := make([]*big.Rat, 50)
+		sum := new(big.Rat)
+		for i := range values {
+			f := FromRaw(1000e7 + r.Int63n(2000e7))
+			v.Add(f)
+			values[i], _ = new(big.Rat).SetString(f.String())
+			sum.Add(sum, values[i])
+		}
+		mean := new(big.Rat).Quo(sum, big.NewRat(int64(len(values)), 1))
+		m2 := new(big.Rat)
+		for _, x := range values {
+			d := new(big.Rat).Sub(x, mean)
+			m2.Add(m2, d.Mul(d, d))
+		}
+		variance := new(big.Rat).Quo(m2, big.NewRat(int64(len(values)-1), 1))
+		if v.Mean().String() != round(mean) {
+			t.Error("should be equal", v.Mean(), round(mean))
+		}
+		if v.Variance().String() != round(variance) {
+			t.Error("should be equal", v.Variance(), round(variance))
+		}
+		// the standard deviation s rounds to x if (x-0.5e-7)^2 <= s^2 < (x+0.5e-7)^2
+		sd, _ := new(big.Rat).SetString(v.StdDev().String())
+		half := big.NewRat(1, 2e7)
+		lo, hi := new(big.Rat).Sub(sd, half), new(big.Rat).Add(sd, half)
+		if lo.Mul(lo, lo).Cmp(variance) > 0 || hi.Mul(hi, hi).Cmp(variance) <= 0 {
+			t.Error("wrong standard deviation", v.StdDev(), variance.FloatString(20))
+		}
+	}
+}
+
+func TestEMA(t *testing.T) {
+	e := NewEMA(NewS("0.5"))
+	if !e.Value().IsNaN() {
+		t.Error("should be NaN", e.Value())
+	}
+	for _, f := range fixeds("10", "20", "20", "0.0000001") {
+		e.Add(f)
+	}
+	if e.Value().String() != "8.75" {
+		t.Error("should be equal", e.Value(), "8.75")
+	}
+	e.Reset()
+	e.Add(NewS("3"))
+	if e.Value().String() != "3" {
+		t.Error("should be equal", e.Value(), "3")
+	}
+}
+
+func TestSMA(t *testing.T) {
+	s := NewSMA(3)
+	if !s.Value().IsNaN() {
+		t.Error("should be NaN", s.Value())
+	}
+	s.Add(NewS("1"))
+	s.Add(NewS("2"))
+	if s.Full() || s.Value().String() != "1.5" {
+		t.Error("should be equal", s.Value(), "1.5")
+	}
+	s.Add(NewS("4"))
+	s.Add(NewS("8"))
+	if !s.Full() || s.Value().String() != "4.6666667" {
+		t.Error("should be equal", s.Value(), "4.6666667")
+	}
+	s.Add(NaN)
+	if !s.Value().IsNaN() {
+		t.Error("should be NaN", s.Value())
+	}
+	s.Add(NewS("1"))
+	s.Add(NewS("1"))
+	s.Add(NewS("1"))
+	if s.Value().String() != "1" {
+		t.Error("should be equal", s.Value(), "1")
+	}
+	s.Reset()
+	if s.Full() || !s.Value().IsNaN() {
+		t.Error("should be empty", s.Value())
+	}
+}
+
+func TestRollingMinMax(t *testing.T) {
+	min, max := NewRollingMin(3), NewRollingMax(3)
+	values := fixeds("5", "3", "4", "6", "7", "1", "2", "2")
+	mins := []string{"5", "3", "3", "3", "4", "1", "1", "1"}
+	maxs := []string{"5", "5", "5", "6", "7", "7", "7", "2"}
+	for i, f := range values {
+		min.Add(f)
+		max.Add(f)
+		if min.Value().String() != mins[i] {
+			t.Error("should be equal", i, min.Value(), mins[i])
+		}
+		if max.Value().String() != maxs[i] {
+			t.Error("should be equal", i, max.Value(), maxs[i])
+		}
+	}
+	min.Add(NaN)
+	if !min.Value().IsNaN() {
+		t.Error("should be NaN", min.Value())
+	}
+	min.Add(NewS("9"))
+	min.Add(NewS("8"))
+	if !min.Value().IsNaN() {
+		t.Error("should be NaN", min.Value())
+	}
+	min.Add(NewS("10"))
+	if min.Value().String() != "8" {
+		t.Error("should be equal", min.Value(), "8")
+	}
+	min.Reset()
+	if !min.Value().IsNaN() {
+		t.Error("should be NaN", min.Value())
+	}
+}
+
+func TestStatsAllocs(t *testing.T) {
+	var v RunningVariance
+	s := NewSMA(10)
+	m := NewRollingMax(10)
+	f := NewS("123.45")
+	allocs := testing.AllocsPerRun(100, func() {
+		v.Add(f)
+		s.Add(f)
+		m.Add(f)
+		_ = v.StdDev()
+		_ = s.Value()
+		_ = m.Value()
+	})
+	if allocs != 0 {
+		t.Error("should not allocate", allocs)
+	}
+}
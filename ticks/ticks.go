@@ -0,0 +1,231 @@
+// Package ticks validates and rounds prices using tiered tick size tables, e.g. a tick of 0.01 below 1.00 and 0.05
+// from 1.00 to 10.00.
+package ticks
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+
+	"github.com/robaho/fixed"
+)
+
+// Side is the side of an order, which determines the direction a price is rounded to a valid tick
+type Side int
+
+const (
+	// Buy rounds prices down, so a buyer never pays more than requested
+	Buy Side = iota
+	// Sell rounds prices up, so a seller never receives less than requested
+	Sell
+)
+
+// maxRaw is the raw value of the largest fixed.Fixed
+const maxRaw = 999999999999999999
+
+var ErrInvalidTable = errors.New("ticks: invalid tick table")
+var ErrInvalidPrice = errors.New("ticks: price is not a valid tick")
+
+// Tier is a range of prices sharing a tick size. A tier applies to the prices from From, inclusive, up to the From
+// of the next tier
+type Tier struct {
+	From fixed.Fixed `json:"from"`
+	Tick fixed.Fixed `json:"tick"`
+}
+
+// Table is a tiered tick size table. A valid price is a multiple of the tick of its tier. Prices below the From of the
+// first tier are invalid, and a zero Table has no tiers, so no price is valid. A Table is immutable and safe for
+// concurrent use
+type Table struct {
+	tiers []Tier
+}
+
+// NewTable creates a Table from tiers in ascending order. Each tick must be positive, and each From must be
+// a multiple of its tick
+func NewTable(tiers ...Tier) (*Table, error) {
+	if len(tiers) == 0 {
+		return nil, ErrInvalidTable
+	}
+	for i, tier := range tiers {
+		if !tier.From.IsFinite() || !tier.Tick.IsFinite() || tier.Tick.Sign() <= 0 ||
+			tier.From.Raw()%tier.Tick.Raw() != 0 {
+			return nil, ErrInvalidTable
+		}
+		if i > 0 && tier.From.Raw() <= tiers[i-1].From.Raw() {
+			return nil, ErrInvalidTable
+		}
+	}
+	return &Table{tiers: append([]Tier(nil), tiers...)}, nil
+}
+
+// LoadTable reads a Table from JSON in the form [{"from":0,"tick":0.01},{"from":1,"tick":0.05}]
+func LoadTable(r io.Reader) (*Table, error) {
+	var tiers []Tier
+	if err := json.NewDecoder(r).Decode(&tiers); err != nil {
+		return nil, err
+	}
+	return NewTable(tiers...)
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (t *Table) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.tiers)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (t *Table) UnmarshalJSON(bytes []byte) error {
+	var tiers []Tier
+	if err := json.Unmarshal(bytes, &tiers); err != nil {
+		return err
+	}
+	t0, err := NewTable(tiers...)
+	if err != nil {
+		return err
+	}
+	*t = *t0
+	return nil
+}
+
+// Tiers returns a copy of the tiers of the table
+func (t *Table) Tiers() []Tier {
+	return append([]Tier(nil), t.tiers...)
+}
+
+// tier returns the index of the tier containing fp, or -1 if fp is below the first tier
+func (t *Table) tier(fp int64) int {
+	i := len(t.tiers) - 1
+	for ; i >= 0; i-- {
+		if fp >= t.tiers[i].From.Raw() {
+			break
+		}
+	}
+	return i
+}
+
+// TickSize returns the tick size for the price, or NaN if the price is NaN, infinite or below the first tier
+func (t *Table) TickSize(price fixed.Fixed) fixed.Fixed {
+	if !price.IsFinite() {
+		return fixed.NaN
+	}
+	i := t.tier(price.Raw())
+	if i < 0 {
+		return fixed.NaN
+	}
+	return t.tiers[i].Tick
+}
+
+// IsValid returns true if the price is a multiple of the tick size of its tier
+func (t *Table) IsValid(price fixed.Fixed) bool {
+	if !price.IsFinite() {
+		return false
+	}
+	i := t.tier(price.Raw())
+	return i >= 0 && price.Raw()%t.tiers[i].Tick.Raw() == 0
+}
+
+// first returns the smallest valid price, or NaN if there are no tiers
+func (t *Table) first() fixed.Fixed {
+	if len(t.tiers) == 0 {
+		return fixed.NaN
+	}
+	return t.tiers[0].From
+}
+
+func floorTo(fp, tick int64) int64 {
+	r := fp % tick
+	if r < 0 {
+		r += tick
+	}
+	return fp - r
+}
+
+// ceil returns the smallest valid price >= fp, where fp is in tier i
+func (t *Table) ceil(fp int64, i int) fixed.Fixed {
+	tick := t.tiers[i].Tick.Raw()
+	c := floorTo(fp, tick)
+	if c < fp {
+		c += tick
+	}
+	if i+1 < len(t.tiers) && c > t.tiers[i+1].From.Raw() {
+		c = t.tiers[i+1].From.Raw()
+	}
+	if c > maxRaw {
+		return fixed.NaN
+	}
+	return fixed.FromRaw(c)
+}
+
+// RoundToTick rounds the price to a valid tick, down for Buy and up for Sell. NaN is returned if the price
+// is NaN or infinite, or there is no valid price in that direction
+func (t *Table) RoundToTick(price fixed.Fixed, side Side) fixed.Fixed {
+	if !price.IsFinite() {
+		return fixed.NaN
+	}
+	i := t.tier(price.Raw())
+	if side == Sell {
+		if i < 0 {
+			return t.first()
+		}
+		return t.ceil(price.Raw(), i)
+	}
+	if i < 0 {
+		return fixed.NaN
+	}
+	return fixed.FromRaw(floorTo(price.Raw(), t.tiers[i].Tick.Raw()))
+}
+
+// NextTick returns the smallest valid price greater than price, or NaN if the price is NaN or infinite
+func (t *Table) NextTick(price fixed.Fixed) fixed.Fixed {
+	if !price.IsFinite() {
+		return fixed.NaN
+	}
+	fp := price.Raw() + 1
+	i := t.tier(fp)
+	if i < 0 {
+		return t.first()
+	}
+	return t.ceil(fp, i)
+}
+
+// PrevTick returns the largest valid price less than price, or NaN if the price is NaN or infinite, or there is no such
+// price
+func (t *Table) PrevTick(price fixed.Fixed) fixed.Fixed {
+	if !price.IsFinite() {
+		return fixed.NaN
+	}
+	fp := price.Raw() - 1
+	i := t.tier(fp)
+	if i < 0 {
+		return fixed.NaN
+	}
+	return fixed.FromRaw(floorTo(fp, t.tiers[i].Tick.Raw()))
+}
+
+// TicksBetween returns the number of ticks from a to b, negative if b is less than a. Both prices must be valid
+func (t *Table) TicksBetween(a, b fixed.Fixed) (int64, error) {
+	if !t.IsValid(a) || !t.IsValid(b) {
+		return 0, ErrInvalidPrice
+	}
+	if b.Raw() < a.Raw() {
+		n, err := t.TicksBetween(b, a)
+		return -n, err
+	}
+	var n int64
+	for i := t.tier(a.Raw()); i < len(t.tiers); i++ {
+		from := a.Raw()
+		if i > 0 && t.tiers[i].From.Raw() > from {
+			from = t.tiers[i].From.Raw()
+		}
+		if from >= b.Raw() {
+			break
+		}
+		to := b.Raw()
+		if i+1 < len(t.tiers) && t.tiers[i+1].From.Raw() < to {
+			to = t.tiers[i+1].From.Raw()
+		}
+		// the upper boundary may not be a multiple of this tier's tick, in which case it is a partial tick
+		tick := t.tiers[i].Tick.Raw()
+		n += (to - from + tick - 1) / tick
+	}
+	return n, nil
+}
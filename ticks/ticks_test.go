@@ -0,0 +1,183 @@
+package ticks
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/robaho/fixed"
+)
+
+func newTable(t *testing.T) *Table {
+	table, err := LoadTable(strings.NewReader(`[
+		{"from":0,"tick":0.01},
+		{"from":1,"tick":0.05},
+		{"from":10,"tick":0.25}
+	]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return table
+}
+
+func TestTableIsValid(t *testing.T) {
+	table := newTable(t)
+	valid := []string{"0", "0.01", "0.99", "1", "1.05", "9.95", "10", "10.25"}
+	for _, s := range valid {
+		if !table.IsValid(fixed.NewS(s)) {
+			t.Error("should be valid", s)
+		}
+	}
+	invalid := []string{"-0.01", "0.001", "1.01", "10.05", "NaN"}
+	for _, s := range invalid {
+		if table.IsValid(fixed.NewS(s)) {
+			t.Error("should not be valid", s)
+		}
+	}
+	if ts := table.TickSize(fixed.NewS("5")); ts.String() != "0.05" {
+		t.Error("should be equal", ts, "0.05")
+	}
+}
+
+func TestRoundToTick(t *testing.T) {
+	table := newTable(t)
+	testCases := []struct {
+		price string
+		side  Side
+		out   string
+	}{
+		{"1.03", Buy, "1"},
+		{"1.03", Sell, "1.05"},
+		{"0.995", Buy, "0.99"},
+		{"0.995", Sell, "1"},
+		{"9.97", Sell, "10"},
+		{"10.1", Buy, "10"},
+		{"1.05", Buy, "1.05"},
+		{"1.05", Sell, "1.05"},
+		{"-1", Sell, "0"},
+		{"-1", Buy, "NaN"},
+	}
+	for _, tc := range testCases {
+		f := table.RoundToTick(fixed.NewS(tc.price), tc.side)
+		if f.String() != tc.out {
+			t.Error("should be equal", tc.price, tc.side, f, tc.out)
+		}
+	}
+}
+
+func TestTableNonMultipleBoundary(t *testing.T) {
+	table, err := NewTable(Tier{From: fixed.NewS("0"), Tick: fixed.NewS("0.03")}, Tier{From: fixed.NewS("1"), Tick: fixed.NewS("0.1")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f := table.RoundToTick(fixed.NewS("0.995"), Sell); f.String() != "1" {
+		t.Error("should be equal", f, "1")
+	}
+	if f := table.NextTick(fixed.NewS("0.99")); f.String() != "1" {
+		t.Error("should be equal", f, "1")
+	}
+	if f := table.PrevTick(fixed.NewS("1")); f.String() != "0.99" {
+		t.Error("should be equal", f, "0.99")
+	}
+	n, err := table.TicksBetween(fixed.NewS("0.96"), fixed.NewS("1.1"))
+	if err != nil || n != 3 {
+		t.Error("should be equal", n, 3, err)
+	}
+}
+
+func TestNextPrevTick(t *testing.T) {
+	table := newTable(t)
+	if f := table.NextTick(fixed.NewS("0.99")); f.String() != "1" {
+		t.Error("should be equal", f, "1")
+	}
+	if f := table.NextTick(fixed.NewS("1")); f.String() != "1.05" {
+		t.Error("should be equal", f, "1.05")
+	}
+	if f := table.NextTick(fixed.NewS("1.02")); f.String() != "1.05" {
+		t.Error("should be equal", f, "1.05")
+	}
+	if f := table.PrevTick(fixed.NewS("1")); f.String() != "0.99" {
+		t.Error("should be equal", f, "0.99")
+	}
+	if f := table.PrevTick(fixed.NewS("10")); f.String() != "9.95" {
+		t.Error("should be equal", f, "9.95")
+	}
+	if f := table.PrevTick(fixed.NewS("1.02")); f.String() != "1" {
+		t.Error("should be equal", f, "1")
+	}
+	if f := table.PrevTick(fixed.NewS("0")); !f.IsNaN() {
+		t.Error("should be NaN", f)
+	}
+	if f := table.NextTick(fixed.NewS("-5")); f.String() != "0" {
+		t.Error("should be equal", f, "0")
+	}
+}
+
+func TestTicksBetween(t *testing.T) {
+	table := newTable(t)
+	testCases := []struct {
+		a, b string
+		n    int64
+	}{
+		{"0.5", "0.6", 10},
+		{"0.99", "1.05", 2},
+		{"0.5", "10.25", 50 + 180 + 1},
+		{"10.25", "0.5", -231},
+		{"1", "1", 0},
+	}
+	for _, tc := range testCases {
+		n, err := table.TicksBetween(fixed.NewS(tc.a), fixed.NewS(tc.b))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n != tc.n {
+			t.Error("should be equal", tc.a, tc.b, n, tc.n)
+		}
+	}
+	if _, err := table.TicksBetween(fixed.NewS("1.01"), fixed.NewS("2")); err != ErrInvalidPrice {
+		t.Error("expected ErrInvalidPrice", err)
+	}
+}
+
+func TestTableInvalid(t *testing.T) {
+	tables := [][]Tier{
+		{},
+		{{From: fixed.NewS("0"), Tick: fixed.ZERO}},
+		{{From: fixed.NewS("0"), Tick: fixed.NewS("0.01")}, {From: fixed.NewS("0"), Tick: fixed.NewS("0.05")}},
+		{{From: fixed.NewS("0"), Tick: fixed.NewS("0.01")}, {From: fixed.NewS("1.01"), Tick: fixed.NewS("0.05")}},
+	}
+	for _, tiers := range tables {
+		if _, err := NewTable(tiers...); err != ErrInvalidTable {
+			t.Error("expected ErrInvalidTable", tiers, err)
+		}
+	}
+}
+
+func TestTableJSON(t *testing.T) {
+	table := newTable(t)
+	data, err := json.Marshal(table)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var table0 Table
+	if err := json.Unmarshal(data, &table0); err != nil {
+		t.Fatal(err)
+	}
+	if len(table0.Tiers()) != 3 || !table0.IsValid(fixed.NewS("10.25")) || table0.IsValid(fixed.NewS("10.1")) {
+		t.Error("round trip failed", string(data))
+	}
+}
+
+func TestZeroTable(t *testing.T) {
+	var table Table
+	price := fixed.NewS("1")
+	if table.IsValid(price) || !table.TickSize(price).IsNaN() {
+		t.Error("should not be valid")
+	}
+	for _, f := range []fixed.Fixed{table.RoundToTick(price, Buy), table.RoundToTick(price, Sell), table.NextTick(price),
+		table.PrevTick(price)} {
+		if !f.IsNaN() {
+			t.Error("should be NaN", f)
+		}
+	}
+}